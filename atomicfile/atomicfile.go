@@ -0,0 +1,83 @@
+// Package atomicfile writes files so that readers never see them half-written,
+// and serializes read-modify-write cycles between processes with advisory locks.
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFile writes the data to the file at path, like os.WriteFile.
+// The data goes to a temporary file in the same directory first, which is synced to disk
+// and then renamed into place. If anything fails, the file at path is left as it was.
+func WriteFile(path string, data []byte, perm os.FileMode) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// Lock takes an exclusive advisory lock for the file at path, waiting for other processes
+// to release theirs. The lock is held on a separate file next to it, with a .lock suffix,
+// so that the file itself can be replaced with WriteFile while it's locked.
+// Call the returned function to release the lock, which also removes the lock file.
+//
+// The lock only keeps out other processes that use Lock. It isn't reentrant:
+// locking the same path twice without releasing it waits forever.
+func Lock(path string) (func() error, error) {
+	lockPath := path + ".lock"
+	for {
+		f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, os.FileMode(0600))
+		if err != nil {
+			return nil, err
+		}
+		if err := lockFile(f); err != nil {
+			f.Close()
+			return nil, err
+		}
+		// While we waited, the previous holder may have removed the lock file, and someone else
+		// may have created a new one. The lock on the removed file keeps nobody out, so start over.
+		if isCurrent(f, lockPath) {
+			return func() error { return release(f, lockPath) }, nil
+		}
+		unlockFile(f)
+		f.Close()
+	}
+}
+
+// isCurrent reports whether the open file is still the one at path.
+func isCurrent(f *os.File, path string) bool {
+	opened, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(opened, current)
+}
@@ -0,0 +1,112 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.json")
+
+	err := WriteFile(path, []byte(`{"token": "abc123"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+	err = WriteFile(path, []byte(`{"token": "xyz"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"token": "xyz"}`, string(b))
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestWriteFileFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.json")
+	err := WriteFile(path, []byte(`{}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	// A directory in the way of the rename makes the write fail.
+	target := filepath.Join(dir, "taken")
+	err = os.MkdirAll(filepath.Join(target, "child"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = WriteFile(target, []byte(`{}`), os.FileMode(0600))
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestLockWithParallelWriters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "counter")
+	err := WriteFile(path, []byte("0"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	const writers, increments = 8, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*increments)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				errs <- increment(path)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(writers*increments), string(b))
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestLockLeavesNoFileBehind(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "counter")
+	err := WriteFile(path, []byte("0"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	err = increment(path)
+	assert.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "counter", entries[0].Name())
+	}
+}
+
+func increment(path string) error {
+	unlock, err := Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(string(b))
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, []byte(strconv.Itoa(n+1)), os.FileMode(0600))
+}
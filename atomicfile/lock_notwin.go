@@ -0,0 +1,45 @@
+//go:build !windows
+
+package atomicfile
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func lockFile(f *os.File) error {
+	for {
+		err := unix.Flock(int(f.Fd()), unix.LOCK_EX)
+		if err != unix.EINTR {
+			return err
+		}
+	}
+}
+
+func unlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
+
+// release removes the lock file while it's still locked, so that nobody who waits for it
+// can lock it in between, and then unlocks it. Those who waited see that it's gone and start over.
+func release(f *os.File, path string) error {
+	// Leaving the lock file behind does no harm, so failing to remove it isn't an error.
+	_ = os.Remove(path)
+	if err := unlockFile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir makes the rename of a file in the directory durable.
+// It's best effort, since not every file system supports syncing a directory.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	d.Close()
+}
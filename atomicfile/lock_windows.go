@@ -0,0 +1,40 @@
+//go:build windows
+
+package atomicfile
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// allBytes locks the whole file, however long it gets.
+const allBytes = ^uint32(0)
+
+func lockFile(f *os.File) error {
+	ol := new(windows.Overlapped)
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, allBytes, allBytes, ol)
+}
+
+func unlockFile(f *os.File) error {
+	ol := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, allBytes, allBytes, ol)
+}
+
+// release unlocks the lock file and then removes it. Windows can't remove a file that is open,
+// so the removal fails while someone else waits for the lock; the last one to release it removes it.
+func release(f *os.File, path string) error {
+	if err := unlockFile(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// Leaving the lock file behind does no harm, so failing to remove it isn't an error.
+	_ = os.Remove(path)
+	return nil
+}
+
+// syncDir does nothing, since Windows can't sync a directory, and the rename is durable anyway.
+func syncDir(dir string) {}
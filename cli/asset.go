@@ -22,7 +22,7 @@ func (a *Asset) download() (*bytes.Reader, error) {
 	}
 	// https://developer.github.com/v3/repos/releases/#get-a-single-release-asset
 	req.Header.Set("Accept", "application/octet-stream")
-	res, err := http.DefaultClient.Do(req)
+	res, err := HTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
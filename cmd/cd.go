@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/cobra"
+)
+
+// cdCmd outputs the path to an exercise within the person's workspace.
+var cdCmd = &cobra.Command{
+	Use:   "cd <EXERCISE>",
+	Short: "Print out the path to an exercise in your workspace.",
+	Long: `Print out the path to an exercise in your workspace.
+
+Name the exercise by its track and slug, e.g. "go/two-fer", or just by
+its slug, e.g. "two-fer", if there is only one exercise with that slug.
+
+A program can't change the directory of the shell it was started from,
+so combine this command with the shell's own cd:
+
+    cd $(exercism cd go/two-fer)
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runCd(cfg, args[0])
+	},
+}
+
+func runCd(cfg config.Config, id string) error {
+	if cfg.UserViperConfig.GetString("workspace") == "" {
+		return fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	dir, err := findExerciseByID(cfg.UserViperConfig, id)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		return exerciseNotFoundError(id)
+	}
+	fmt.Fprintf(Out, "%s\n", dir)
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(cdCmd)
+}
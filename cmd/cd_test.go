@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCdWorkspace(t *testing.T) (string, config.Config) {
+	return setupTestWorkspace(t,
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "two-fer", IsRequester: true},
+		workspace.ExerciseMetadata{Track: "python", ExerciseSlug: "two-fer", IsRequester: true},
+		workspace.ExerciseMetadata{Track: "python", ExerciseSlug: "leap", IsRequester: true},
+	)
+}
+
+func TestCd(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	tmpDir, cfg := setupCdWorkspace(t)
+
+	err := runCd(cfg, "leap")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "python", "leap")+"\n", Out.(*bytes.Buffer).String())
+
+	Out.(*bytes.Buffer).Reset()
+	err = runCd(cfg, "go/two-fer")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "go", "two-fer")+"\n", Out.(*bytes.Buffer).String())
+
+	err = runCd(cfg, "go/leap")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "download --exercise=leap --track=go")
+	}
+}
+
+func TestCdAmbiguous(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	tmpDir, cfg := setupCdWorkspace(t)
+
+	oldIsInteractive, oldIn := isInteractive, In
+	defer func() { isInteractive, In = oldIsInteractive, oldIn }()
+
+	isInteractive = func() bool { return false }
+	err := runCd(cfg, "two-fer")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "go/two-fer")
+		assert.Contains(t, err.Error(), "python/two-fer")
+	}
+
+	isInteractive = func() bool { return true }
+	In = strings.NewReader("2\n")
+	err = runCd(cfg, "two-fer")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, "python", "two-fer")+"\n", Out.(*bytes.Buffer).String())
+
+	In = strings.NewReader("3\n")
+	err = runCd(cfg, "two-fer")
+	assert.Error(t, err)
+}
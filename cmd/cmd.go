@@ -1,17 +1,22 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"io"
 
 	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
 	"github.com/spf13/viper"
 )
 
@@ -26,6 +31,8 @@ var (
 	Out io.Writer
 	// Err is used to write errors.
 	Err io.Writer
+	// In is used to read answers to questions.
+	In io.Reader
 	// jsonContentTypeRe is used to match Content-Type which contains JSON.
 	jsonContentTypeRe = regexp.MustCompile(`^application/([[:alpha:]]+\+)?json($|;)`)
 )
@@ -60,12 +67,28 @@ const msgRerunConfigure = `
 const msgMissingMetadata = `
 
     The exercise you are submitting doesn't have the necessary metadata.
-    Please see https://github.com/exercism/website-copy/blob/main/pages/cli_v1_to_v2.md for instructions on how to fix it.
+    Run the doctor to find out what's wrong, and repair it if possible:
+
+        %s doctor --fix
 
 `
 
-// validateUserConfig validates the presence of required user config values
-func validateUserConfig(cfg *viper.Viper) error {
+const msgNotInExercise = `
+
+    This command needs to be run from within an exercise directory,
+    or one of its subdirectories.
+
+`
+
+// validateUserConfig validates the presence of required user config values.
+// On first use, with someone at the terminal, it walks through the setup instead.
+func validateUserConfig(configuration config.Config) error {
+	cfg := configuration.UserViperConfig
+	if cfg.GetString("token") == "" && canRunSetup() {
+		if err := runConfigureInteractive(configuration); err != nil {
+			return err
+		}
+	}
 	if cfg.GetString("token") == "" {
 		return fmt.Errorf(
 			msgWelcomePleaseConfigure,
@@ -79,6 +102,141 @@ func validateUserConfig(cfg *viper.Viper) error {
 	return nil
 }
 
+// findExerciseDir finds the root of the exercise that contains path.
+func findExerciseDir(path string) (string, error) {
+	dir, err := workspace.FindExerciseDir(path)
+	if workspace.IsMissingMetadata(err) {
+		return "", errors.New(msgNotInExercise)
+	}
+	return dir, err
+}
+
+// isInteractive reports whether someone is at the terminal to answer questions.
+var isInteractive = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// isTerminalOutput reports whether standard output goes to a terminal, rather than to a file or a pipe.
+var isTerminalOutput = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// findExerciseByID looks up one of the user's exercises by its ID, which is a slug
+// such as "two-fer", or a track and a slug such as "go/two-fer".
+// It returns the exercise directory, or an empty string if there is no such exercise.
+// If the ID matches several exercises it asks which one is meant, or, if nobody
+// is there to answer, returns an error that lists them.
+func findExerciseByID(usrCfg *viper.Viper, id string) (string, error) {
+	if usrCfg.GetString("workspace") == "" {
+		return "", nil
+	}
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return "", err
+	}
+	exercises, err := ws.FindExercises(id)
+	if err != nil {
+		return "", err
+	}
+	switch len(exercises) {
+	case 0:
+		return "", nil
+	case 1:
+		return exercises[0].Filepath(), nil
+	}
+
+	sort.Slice(exercises, func(i, j int) bool { return exercises[i].Path() < exercises[j].Path() })
+	if !isInteractive() {
+		paths := make([]string, 0, len(exercises))
+		for _, exercise := range exercises {
+			paths = append(paths, exercise.Path())
+		}
+		msg := `
+
+    '%s' matches more than one exercise in your workspace:
+
+        %s
+
+    Please include the track, e.g. '%s'.
+
+`
+		return "", fmt.Errorf(msg, id, strings.Join(paths, "\n        "), exercises[0].Path())
+	}
+
+	fmt.Fprintf(Err, "\n'%s' matches more than one exercise in your workspace:\n\n", id)
+	for i, exercise := range exercises {
+		fmt.Fprintf(Err, "    %d) %s\n", i+1, exercise.Path())
+	}
+	fmt.Fprintf(Err, "\nWhich one do you mean? [1-%d] ", len(exercises))
+	answer, err := bufio.NewReader(In).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > len(exercises) {
+		return "", fmt.Errorf("'%s' is not one of the choices", strings.TrimSpace(answer))
+	}
+	return exercises[choice-1].Filepath(), nil
+}
+
+// autocommit commits the exercise to git, if the user opted in and git is installed.
+// The command has done its work by then, so failing to commit is only a warning.
+func autocommit(usrCfg *viper.Viper, metadata *workspace.ExerciseMetadata, action string) {
+	scope := usrCfg.GetString("autocommit")
+	if scope == "" || !workspace.GitAvailable() {
+		return
+	}
+	committer := workspace.GitCommitter{Root: usrCfg.GetString("workspace"), Scope: scope}
+	if err := committer.Commit(metadata, metadata.CommitMessage(action)); err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Unable to commit the exercise to git: %s\n", err)
+	}
+}
+
+// findExerciseByArg resolves an argument that may name an exercise, either by the path to its directory,
+// or by its ID. Within the workspace an ID is often a relative path too, e.g. "go/two-fer" from the
+// workspace root, or "two-fer" from the track directory.
+// It returns the exercise directory, or an empty string if the argument doesn't name an exercise,
+// and whether the argument is an existing path.
+func findExerciseByArg(usrCfg *viper.Viper, arg string) (string, bool, error) {
+	pathType, err := workspace.DetectPathType(arg)
+	if err != nil {
+		return "", false, err
+	}
+	switch pathType {
+	case workspace.TypeFile:
+		return "", true, nil
+	case workspace.TypeDir:
+		if dir, err := workspace.FindExerciseDir(arg); err == nil {
+			if abs, err := filepath.Abs(arg); err == nil && abs == dir {
+				return dir, true, nil
+			}
+		}
+		dir, err := findExerciseByID(usrCfg, arg)
+		return dir, true, err
+	}
+	dir, err := findExerciseByID(usrCfg, arg)
+	return dir, false, err
+}
+
+// exerciseNotFoundError explains that an exercise ID doesn't match anything in the workspace.
+func exerciseNotFoundError(id string) error {
+	msg := `
+
+    There is no exercise '%s' in your workspace.
+    Download it first:
+
+        %s download --exercise=%s
+
+`
+	slug := id
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		slug = fmt.Sprintf("%s --track=%s", id[i+1:], id[:i])
+	}
+	return fmt.Errorf(msg, id, BinaryName, slug)
+}
+
 // decodedAPIError decodes and returns the error message from the API response.
 // If the message is blank, it returns a fallback message with the status code.
 func decodedAPIError(resp *http.Response) error {
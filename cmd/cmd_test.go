@@ -5,10 +5,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -96,6 +100,30 @@ func (test *CommandTest) Teardown(t *testing.T) {
 	}
 }
 
+// setupTestWorkspace creates a workspace in a temporary directory that is removed after the test,
+// writes the given exercises to where they belong in it, and returns it with a config that points to it.
+func setupTestWorkspace(t *testing.T, exercises ...workspace.ExerciseMetadata) (string, config.Config) {
+	t.Helper()
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	for _, em := range exercises {
+		writeTestExercise(t, em.Exercise(dir).MetadataDir(), em)
+	}
+
+	v := viper.New()
+	v.Set("workspace", dir)
+	return dir, config.Config{UserViperConfig: v}
+}
+
+// writeTestExercise writes an exercise with its metadata and a solution.txt to dir.
+func writeTestExercise(t *testing.T, dir string, em workspace.ExerciseMetadata) {
+	t.Helper()
+	err := em.Write(dir)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "solution.txt"), []byte("solution"), os.FileMode(0644))
+	assert.NoError(t, err)
+}
+
 // capturedOutput lets us more easily redirect streams in the tests.
 type capturedOutput struct {
 	oldOut, oldErr, newOut, newErr io.Writer
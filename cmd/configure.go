@@ -8,15 +8,13 @@ import (
 
 	"github.com/exercism/cli/api"
 	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/debug"
+	"github.com/exercism/cli/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-var (
-	viperConfig *viper.Viper
-)
-
 // configureCmd configures the command-line client with user-specific settings.
 var configureCmd = &cobra.Command{
 	Use:     "configure",
@@ -29,16 +27,31 @@ and tells the CLI about your setup so it puts things in the right
 places.
 
 You can also override certain default settings to suit your preferences.
+
+To keep separate settings for another account or server, configure
+a profile with --profile=NAME. See "profiles".
+
+The token is kept in the config file, unless you configure a credential
+helper with --credential-helper. That is a program which keeps the token
+elsewhere, e.g. in a password manager or keyring. It speaks the protocol
+of git's credential helpers, so those work too, e.g.
+--credential-helper="git credential-libsecret".
+
+Run with --interactive to be asked for the token without it showing up
+in your shell history. This also happens the first time you use the CLI.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		configuration := config.NewConfig()
-
-		viperConfig.AddConfigPath(configuration.Dir)
-		viperConfig.SetConfigName("user")
-		viperConfig.SetConfigType("json")
-		// Ignore error. If the file doesn't exist, that is fine.
-		_ = viperConfig.ReadInConfig()
-		configuration.UserViperConfig = viperConfig
+		// The local --token, --workspace and --api flags are the values to configure,
+		// so only the global flags override the config.
+		// Configuring a profile that doesn't exist yet creates it.
+		configuration, err := loadConfig(cmd.InheritedFlags(), true)
+		if err != nil {
+			return err
+		}
+		// A broken credential helper shouldn't stop anyone from configuring a different one.
+		if err := configuration.LoadCredentials(); err != nil {
+			fmt.Fprintf(Err, "\n    WARNING: Unable to get the token from the credential helper: %s\n", err)
+		}
 
 		return runConfigure(configuration, cmd.Flags())
 	},
@@ -46,6 +59,8 @@ You can also override certain default settings to suit your preferences.
 
 func runConfigure(configuration config.Config, flags *pflag.FlagSet) error {
 	cfg := configuration.UserViperConfig
+	previousHelper := configuration.CredentialHelper()
+	previousCredential := configuration.Credential()
 
 	// Show the existing configuration and exit.
 	show, err := flags.GetBool("show")
@@ -57,8 +72,15 @@ func runConfigure(configuration config.Config, flags *pflag.FlagSet) error {
 		return nil
 	}
 
-	// If the command is run 'bare' and we have no token,
-	// explain how to set the token.
+	interactive, err := flags.GetBool("interactive")
+	if err != nil {
+		return err
+	}
+	// If the command is run 'bare' and we have no token, walk through the setup,
+	// or, if nobody is there to answer, explain how to set the token.
+	if interactive || (flags.NFlag() == 0 && cfg.GetString("token") == "" && canRunSetup()) {
+		return runConfigureInteractive(configuration)
+	}
 	if flags.NFlag() == 0 && cfg.GetString("token") == "" {
 		tokenURL := config.TokenURL(cfg.GetString("apibaseurl"))
 		return fmt.Errorf("There is no token configured. Find your token on %s, and call this command again with --token=<your-token>.", tokenURL)
@@ -184,15 +206,57 @@ func runConfigure(configuration config.Config, flags *pflag.FlagSet) error {
 			return fmt.Errorf(msg, workspace, BinaryName, commandify(flags), workspace)
 		}
 	}
+	// Determine whether to commit exercises to git.
+	if flags.Changed("autocommit") {
+		scope, err := flags.GetString("autocommit")
+		if err != nil {
+			return err
+		}
+		if err := setAutocommit(cfg, scope); err != nil {
+			return err
+		}
+	}
+
 	// Configure the workspace.
+	previousWorkspace := cfg.GetString("workspace")
 	cfg.Set("workspace", workspace)
+	// A new profile only borrowed the previous workspace.
+	newProfile := configuration.Profile != "" && !config.ProfileExists(configuration.Dir, configuration.Profile)
+
+	// Determine where to keep the token.
+	if flags.Changed("credential-helper") {
+		helper, err := flags.GetString("credential-helper")
+		if err != nil {
+			return err
+		}
+		cfg.Set("credentialhelper", helper)
+	}
+	if err := storeToken(configuration, previousHelper, previousCredential, token); err != nil {
+		return err
+	}
 
 	// Persist the new configuration.
 	if err := configuration.Save("user"); err != nil {
 		return err
 	}
+	cfg.Set("token", token)
 	fmt.Fprintln(Err, "\nYou have configured the Exercism command-line client:")
 	printCurrentConfig(configuration)
+
+	if previousWorkspace != "" && previousWorkspace != workspace && !newProfile {
+		if _, err := os.Stat(previousWorkspace); err == nil {
+			msg := `
+    Your exercises are still in the previous workspace:
+
+      %s
+
+    To move a workspace along with its exercises, use
+
+      %s workspace move NEWPATH
+`
+			fmt.Fprintf(Err, msg, previousWorkspace, BinaryName)
+		}
+	}
 	return nil
 }
 
@@ -204,12 +268,65 @@ func printCurrentConfig(configuration config.Config) {
 
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, fmt.Sprintf("Config dir:\t\t%s", configuration.Dir))
-	fmt.Fprintln(w, fmt.Sprintf("Token:\t(-t, --token)\t%s", v.GetString("token")))
+	if configuration.Profile != "" {
+		fmt.Fprintln(w, fmt.Sprintf("Profile:\t(--profile)\t%s", configuration.Profile))
+	}
+	fmt.Fprintln(w, fmt.Sprintf("Token:\t(-t, --token)\t%s", debug.Redact(v.GetString("token"))))
+	credentials := "config file"
+	if helper := configuration.CredentialHelper(); helper != nil {
+		credentials = helper.Command
+	}
+	fmt.Fprintln(w, fmt.Sprintf("Token storage:\t(--credential-helper)\t%s", credentials))
 	fmt.Fprintln(w, fmt.Sprintf("Workspace:\t(-w, --workspace)\t%s", v.GetString("workspace")))
 	fmt.Fprintln(w, fmt.Sprintf("API Base URL:\t(-a, --api)\t%s", v.GetString("apibaseurl")))
+	autocommit := v.GetString("autocommit")
+	if autocommit == "" {
+		autocommit = "off"
+	}
+	fmt.Fprintln(w, fmt.Sprintf("Auto-commit:\t(--autocommit)\t%s", autocommit))
 	fmt.Fprintln(w, "")
 }
 
+// storeToken hands the token to the credential helper, if one is configured, rather than keeping it
+// in the config file. A credential helper that has been replaced is told to forget the token.
+func storeToken(configuration config.Config, previous *config.CredentialHelper, previousCredential config.Credential, token string) error {
+	helper := configuration.CredentialHelper()
+	if helper != nil {
+		credential := configuration.Credential()
+		credential.Password = token
+		if err := helper.Store(credential); err != nil {
+			return err
+		}
+		configuration.UserViperConfig.Set("token", "")
+	}
+	if previous != nil && (helper == nil || helper.Command != previous.Command) {
+		previousCredential.Password = token
+		if err := previous.Erase(previousCredential); err != nil {
+			fmt.Fprintf(Err, "\n    WARNING: Unable to erase the token from the previous credential helper: %s\n", err)
+		}
+	}
+	return nil
+}
+
+// setAutocommit configures where exercises are committed to git after a submit or download.
+// The scope "off" turns auto-commits off.
+func setAutocommit(cfg *viper.Viper, scope string) error {
+	if scope == "off" {
+		cfg.Set("autocommit", "")
+		return nil
+	}
+	for _, valid := range workspace.GitScopes {
+		if scope == valid {
+			cfg.Set("autocommit", scope)
+			if !workspace.GitAvailable() {
+				fmt.Fprintln(Err, "\n    WARNING: git isn't installed, so nothing will be committed until it is.")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q for --autocommit, use one of: %s, off", scope, strings.Join(workspace.GitScopes, ", "))
+}
+
 func commandify(flags *pflag.FlagSet) string {
 	var cmd string
 	fn := func(f *pflag.Flag) {
@@ -222,7 +339,6 @@ func commandify(flags *pflag.FlagSet) string {
 }
 
 func initConfigureCmd() {
-	viperConfig = viper.New()
 	setupConfigureFlags(configureCmd.Flags())
 }
 
@@ -231,7 +347,10 @@ func setupConfigureFlags(flags *pflag.FlagSet) {
 	flags.StringP("workspace", "w", "", "directory for exercism exercises")
 	flags.StringP("api", "a", "", "API base url")
 	flags.BoolP("show", "s", false, "show the current configuration")
+	flags.BoolP("interactive", "i", false, "set up the token and workspace by answering questions")
 	flags.BoolP("no-verify", "", false, "skip online token authorization check")
+	flags.StringP("credential-helper", "", "", "program that keeps the token, e.g. in a password manager, instead of the config file; empty to use the config file")
+	flags.StringP("autocommit", "", "", "commit exercises to a git repository per 'workspace' or 'track' after submit and download, or 'off'")
 }
 
 func init() {
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/cobra"
+)
+
+// configureGetCmd prints the value of a single configuration key.
+var configureGetCmd = &cobra.Command{
+	Use:   "get <KEY>",
+	Short: "Print the value of a configuration key.",
+	Long: `Print the value of a configuration key.
+
+The value takes flags, environment variables and the active profile into account.
+Keys within a map are separated by a dot, e.g. test_commands.go.
+
+Run 'exercism configure list' to see all of the keys.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		if err := cfg.LoadCredentials(); err != nil {
+			return err
+		}
+
+		return runConfigureGet(cfg, args[0])
+	},
+}
+
+func runConfigureGet(cfg config.Config, key string) error {
+	v := cfg.UserViperConfig
+	if !v.IsSet(key) {
+		return fmt.Errorf("%s is not set", key)
+	}
+	fmt.Fprintln(Out, v.Get(key))
+	return nil
+}
+
+func init() {
+	configureCmd.AddCommand(configureGetCmd)
+}
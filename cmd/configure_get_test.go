@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureGet(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	cfg := loadSettingsTestConfig(t, `{"autocommit": "track", "test_commands": {"go": "go test"}}`)
+
+	err := runConfigureGet(cfg, "test_commands.go")
+	assert.NoError(t, err)
+	err = runConfigureGet(cfg, "autocommit")
+	assert.NoError(t, err)
+	assert.Equal(t, "go test\ntrack\n", Out.(*bytes.Buffer).String())
+
+	err = runConfigureGet(cfg, "credentialhelper")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "credentialhelper is not set")
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/exercism/cli/api"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/shell"
+	"golang.org/x/term"
+)
+
+// maxTokenAttempts is how often the wizard asks for a token before it gives up.
+const maxTokenAttempts = 3
+
+const msgWelcomeInteractive = `
+    Welcome to Exercism!
+
+    Let's set up the command-line client. You need your API token,
+    which you can find at
+
+        %s
+
+`
+
+// canRunSetup reports whether someone is typing at a terminal, who can answer the questions
+// of the setup. Unlike isInteractive, this excludes devices such as /dev/null.
+var canRunSetup = func() bool {
+	return In == os.Stdin && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// readSecret reads a line from the terminal without showing it.
+// Tests replace it, since they don't run in a terminal.
+var readSecret = func(r *bufio.Reader) (string, error) {
+	if !canRunSetup() {
+		return readLine(r)
+	}
+	fd := int(os.Stdin.Fd())
+	state, err := term.GetState(fd)
+	if err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Unable to hide the input (%s), so the token will be visible.\n", err)
+		return readLine(r)
+	}
+
+	// ReadPassword leaves the input hidden when Ctrl+C stops the process in the middle of it.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-signals:
+			_ = term.Restore(fd, state)
+			fmt.Fprintln(Err)
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(Err)
+	if err == io.EOF && len(secret) == 0 {
+		return "", errNoAnswer
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// errNoAnswer is returned when the input ends before a question is answered.
+var errNoAnswer = errors.New("the setup was cancelled before it was complete")
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", errNoAnswer
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// configureWizard asks the questions of the interactive setup.
+type configureWizard struct {
+	configuration config.Config
+	r             *bufio.Reader
+}
+
+// runConfigureInteractive walks through configuring the token and the workspace,
+// and offers to install the shell completions.
+func runConfigureInteractive(configuration config.Config) error {
+	cfg := configuration.UserViperConfig
+	w := &configureWizard{configuration: configuration, r: bufio.NewReader(In)}
+	previousHelper := configuration.CredentialHelper()
+	previousCredential := configuration.Credential()
+
+	baseURL := cfg.GetString("apibaseurl")
+	if baseURL == "" {
+		baseURL = configuration.DefaultBaseURL
+	}
+	fmt.Fprintf(Err, msgWelcomeInteractive, config.TokenURL(baseURL))
+
+	token, err := w.askToken(baseURL, cfg.GetString("token"))
+	if err != nil {
+		return err
+	}
+	workspace, err := w.askWorkspace()
+	if err != nil {
+		return err
+	}
+
+	cfg.Set("apibaseurl", baseURL)
+	cfg.Set("token", token)
+	cfg.Set("workspace", workspace)
+	if err := storeToken(configuration, previousHelper, previousCredential, token); err != nil {
+		return err
+	}
+	if err := configuration.Save("user"); err != nil {
+		return err
+	}
+	cfg.Set("token", token)
+
+	if err := w.offerCompletions(os.Getenv("SHELL")); err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Unable to install the shell completions: %s\n", err)
+	}
+
+	fmt.Fprintln(Err, "\nYou have configured the Exercism command-line client:")
+	printCurrentConfig(configuration)
+	return nil
+}
+
+// askToken asks for the token until the API accepts it.
+// Answering nothing keeps the configured token, if there is one.
+func (w *configureWizard) askToken(baseURL, configured string) (string, error) {
+	client, err := api.NewClient("", baseURL)
+	if err != nil {
+		return "", err
+	}
+	for attempt := 1; ; attempt++ {
+		if configured != "" {
+			fmt.Fprint(Err, "Token (leave empty to keep the configured one): ")
+		} else {
+			fmt.Fprint(Err, "Token: ")
+		}
+		token, err := readSecret(w.r)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			token = configured
+		}
+		if token != "" {
+			client.Token = token
+			ok, err := client.TokenIsValid()
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				return token, nil
+			}
+		}
+		if attempt == maxTokenAttempts {
+			return "", fmt.Errorf("There is no valid token. Find your token on %s.", config.TokenURL(baseURL))
+		}
+		if token == "" {
+			fmt.Fprintln(Err, "A token is needed to talk to the API, please paste it.")
+		} else {
+			fmt.Fprintln(Err, "That token is invalid, please try again.")
+		}
+	}
+}
+
+// askWorkspace asks where to put the exercises, suggesting the configured or the default workspace.
+// It asks again if there is a file in the way, and checks before using a directory that isn't empty.
+func (w *configureWizard) askWorkspace() (string, error) {
+	suggestion := w.configuration.UserViperConfig.GetString("workspace")
+	if suggestion == "" {
+		suggestion = config.DefaultWorkspaceDir(w.configuration)
+	}
+	for {
+		fmt.Fprintf(Err, "Workspace [%s]: ", suggestion)
+		answer, err := readLine(w.r)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = suggestion
+		}
+		workspace := config.Resolve(answer, w.configuration.Home)
+
+		info, err := os.Lstat(workspace)
+		if os.IsNotExist(err) {
+			return workspace, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !info.IsDir() {
+			fmt.Fprintf(Err, "There is already a file at %s, please choose a different location.\n", workspace)
+			continue
+		}
+		entries, err := os.ReadDir(workspace)
+		if err != nil {
+			return "", err
+		}
+		if len(entries) == 0 || workspace == w.configuration.UserViperConfig.GetString("workspace") {
+			return workspace, nil
+		}
+		ok, err := w.confirm(fmt.Sprintf("The directory %s isn't empty. Use it anyway?", workspace))
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return workspace, nil
+		}
+	}
+}
+
+// offerCompletions offers to install the completion script for the shell, if it's one we have a script for.
+func (w *configureWizard) offerCompletions(shellPath string) error {
+	name := filepath.Base(shellPath)
+	script, path, hint := completionTarget(name, w.configuration)
+	if script == "" {
+		return nil
+	}
+	ok, err := w.confirm(fmt.Sprintf("Install the completions for %s?", name))
+	if err != nil || !ok {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(script), os.FileMode(0644)); err != nil {
+		return err
+	}
+	fmt.Fprintf(Err, "\nInstalled the completions in %s\n", path)
+	if hint != "" {
+		fmt.Fprintf(Err, "%s\n", hint)
+	}
+	return nil
+}
+
+// completionTarget finds the completion script for the shell, where to install it,
+// and what else to do to load it. The script is empty for a shell we don't have one for.
+func completionTarget(shellName string, configuration config.Config) (script, path, hint string) {
+	home := configuration.Home
+	switch shellName {
+	case "bash":
+		path = filepath.Join(configuration.Dir, "exercism_completion.bash")
+		return shell.Bash, path, fmt.Sprintf("Load them in your .bashrc with:\n\n    source %s\n", path)
+	case "zsh":
+		dir := filepath.Join(home, ".zsh", "functions")
+		return shell.Zsh, filepath.Join(dir, "_exercism"), fmt.Sprintf("Load them in your .zshrc, before compinit, with:\n\n    fpath=(%s $fpath)\n", dir)
+	case "fish":
+		return shell.Fish, filepath.Join(home, ".config", "fish", "completions", "exercism.fish"), ""
+	}
+	return "", "", ""
+}
+
+func (w *configureWizard) confirm(question string) (bool, error) {
+	fmt.Fprintf(Err, "%s [y/N] ", question)
+	answer, err := readLine(w.r)
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/shell"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// Tests that run without a token must not start the setup, even when they run in a terminal.
+	canRunSetup = func() bool { return false }
+}
+
+// newWizardTestConfig loads an empty config in a temporary config dir and home,
+// talking to an API that only accepts the token "good-token".
+func newWizardTestConfig(t *testing.T) config.Config {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/validate_token" && r.Header.Get("Authorization") != "Bearer good-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	cfg := loadSettingsTestConfig(t, `{}`)
+	cfg.Home = t.TempDir()
+	cfg.DefaultBaseURL = ts.URL
+	cfg.UserViperConfig.SetDefault("apibaseurl", ts.URL)
+	cfg.DefaultDirName = "exercism"
+	return cfg
+}
+
+func TestConfigureInteractive(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	oldIn := In
+	defer func() { In = oldIn }()
+	t.Setenv("SHELL", "/usr/bin/fish")
+
+	cfg := newWizardTestConfig(t)
+	// An invalid token, a valid one, the suggested workspace, and yes to the completions.
+	In = strings.NewReader("bad-token\ngood-token\n\ny\n")
+
+	err := runConfigureInteractive(cfg)
+	assert.NoError(t, err)
+
+	v := readSettingsTestConfig(t, cfg)
+	assert.Equal(t, "good-token", v.GetString("token"))
+	assert.Equal(t, filepath.Join(cfg.Home, "exercism"), v.GetString("workspace"))
+	assert.Equal(t, cfg.DefaultBaseURL, v.GetString("apibaseurl"))
+
+	b, err := os.ReadFile(filepath.Join(cfg.Home, ".config", "fish", "completions", "exercism.fish"))
+	assert.NoError(t, err)
+	assert.Equal(t, shell.Fish, string(b))
+}
+
+func TestConfigureInteractiveWorkspaceConflicts(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	oldIn := In
+	defer func() { In = oldIn }()
+	t.Setenv("SHELL", "")
+	Err = &bytes.Buffer{}
+
+	cfg := newWizardTestConfig(t)
+	file := filepath.Join(cfg.Home, "file")
+	err := os.WriteFile(file, []byte("in the way"), os.FileMode(0600))
+	assert.NoError(t, err)
+	full := filepath.Join(cfg.Home, "full")
+	err = os.MkdirAll(filepath.Join(full, "projects"), os.FileMode(0755))
+	assert.NoError(t, err)
+
+	// A file, a directory with things in it which is turned down and then accepted.
+	In = strings.NewReader("good-token\n" + file + "\n" + full + "\nn\n" + full + "\nyes\n")
+
+	err = runConfigureInteractive(cfg)
+	assert.NoError(t, err)
+	v := readSettingsTestConfig(t, cfg)
+	assert.Equal(t, full, v.GetString("workspace"))
+	assert.Contains(t, Err.(*bytes.Buffer).String(), "There is already a file at "+file)
+}
+
+func TestConfigureInteractiveGivesUp(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	oldIn := In
+	defer func() { In = oldIn }()
+
+	testCases := []struct {
+		desc    string
+		input   string
+		message string
+	}{
+		{"invalid tokens", "bad\nworse\nworst\n", "no valid token"},
+		{"input ends", "bad\n", "cancelled"},
+	}
+	for _, tc := range testCases {
+		cfg := newWizardTestConfig(t)
+		In = strings.NewReader(tc.input)
+
+		err := runConfigureInteractive(cfg)
+		if assert.Error(t, err, tc.desc) {
+			assert.Contains(t, err.Error(), tc.message, tc.desc)
+		}
+		v := readSettingsTestConfig(t, cfg)
+		assert.False(t, v.IsSet("token"), tc.desc)
+	}
+}
+
+func TestConfigureInteractiveFlag(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	oldIn := In
+	defer func() { In = oldIn }()
+	t.Setenv("SHELL", "")
+
+	cfg := newWizardTestConfig(t)
+	In = strings.NewReader("good-token\n\n")
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupConfigureFlags(flags)
+	err := flags.Parse([]string{"--interactive"})
+	assert.NoError(t, err)
+
+	err = runConfigure(cfg, flags)
+	assert.NoError(t, err)
+	v := readSettingsTestConfig(t, cfg)
+	assert.Equal(t, "good-token", v.GetString("token"))
+}
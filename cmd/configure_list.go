@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/debug"
+	"github.com/spf13/cobra"
+)
+
+// configureListCmd lists the configuration, along with where each value came from.
+var configureListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configuration keys and where their values came from.",
+	Long: `List the configuration keys, their values, and where each value came from:
+the config file, the profile, a flag, an environment variable, the credential
+helper, or the default.
+
+The token is redacted.
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		if err := cfg.LoadCredentials(); err != nil {
+			fmt.Fprintf(Err, "\n    WARNING: Unable to get the token from the credential helper: %s\n", err)
+		}
+
+		return runConfigureList(cfg)
+	},
+}
+
+func runConfigureList(cfg config.Config) error {
+	v := cfg.UserViperConfig
+	keys := v.AllKeys()
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, key := range keys {
+		if key == config.VersionKey {
+			continue
+		}
+		value := fmt.Sprint(v.Get(key))
+		if value == "" {
+			continue
+		}
+		if setting, ok := config.LookupSetting(key); ok && setting.Secret {
+			value = debug.Redact(value)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", key, value, cfg.Source(key))
+	}
+	return w.Flush()
+}
+
+func init() {
+	configureCmd.AddCommand(configureListCmd)
+}
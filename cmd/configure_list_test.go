@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureList(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	cfg := loadSettingsTestConfig(t, `{"token": "abcdefghijkl1234", "autocommit": "track", "test_commands": {"go": "go test"}}`)
+	t.Setenv("EXERCISM_WORKSPACE", "/env-workspace")
+	cfg, err := config.Load(nil, false)
+	assert.NoError(t, err)
+
+	err = runConfigureList(cfg)
+	assert.NoError(t, err)
+
+	expected := `KEY               VALUE                        SOURCE
+apibaseurl        https://api.exercism.org/v1  default
+autocommit        track                        ` + cfg.File + `
+test_commands.go  go test                      ` + cfg.File + `
+token             abcd*********234             ` + cfg.File + `
+workspace         /env-workspace               EXERCISM_WORKSPACE
+`
+	assert.Equal(t, expected, Out.(*bytes.Buffer).String())
+}
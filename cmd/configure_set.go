@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/cobra"
+)
+
+// configureSetCmd sets the value of a single configuration key.
+var configureSetCmd = &cobra.Command{
+	Use:   "set <KEY> <VALUE>",
+	Short: "Set the value of a configuration key.",
+	Long: `Set the value of a configuration key.
+
+The value is checked before it is saved: the workspace is resolved to an
+absolute path, the API base URL must be an http or https URL, and autocommit
+must be 'workspace' or 'track'. With a credential helper configured, the token
+is stored by the helper instead of the config file.
+
+With a profile selected, the token, workspace and API base URL are saved to
+the profile, which is created if it doesn't exist yet.
+
+Keys:
+` + settingsHelp() + `
+	`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), true)
+		if err != nil {
+			return err
+		}
+
+		return runConfigureSet(cfg, args[0], args[1])
+	},
+}
+
+func runConfigureSet(cfg config.Config, key, value string) error {
+	setting, ok := config.LookupSetting(key)
+	if !ok {
+		return fmt.Errorf("unknown configuration key %q, use one of: %s", key, strings.Join(config.SettingKeys(), ", "))
+	}
+	value, err := setting.Parse(value, cfg.Home)
+	if err != nil {
+		return err
+	}
+
+	cfg.UserViperConfig.Set(key, value)
+	if key == "token" {
+		if err := storeToken(cfg, nil, config.Credential{}, value); err != nil {
+			return err
+		}
+	}
+	return cfg.Save("user")
+}
+
+// settingsHelp describes the known configuration keys, one per line.
+func settingsHelp() string {
+	var b strings.Builder
+	for _, setting := range config.Settings {
+		fmt.Fprintf(&b, "    %-18s %s\n", setting.Key, setting.Description)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func init() {
+	configureCmd.AddCommand(configureSetCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadSettingsTestConfig loads the config from a user.json with the contents, in a temporary config dir.
+func loadSettingsTestConfig(t *testing.T, contents string) config.Config {
+	dir := t.TempDir()
+	t.Setenv("EXERCISM_CONFIG_HOME", dir)
+	t.Setenv(config.ProfileEnvVar, "")
+	for _, env := range config.EnvVars {
+		t.Setenv(env, "")
+	}
+	err := os.WriteFile(filepath.Join(dir, "user.json"), []byte(contents), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	cfg, err := config.Load(nil, false)
+	assert.NoError(t, err)
+	return cfg
+}
+
+func readSettingsTestConfig(t *testing.T, cfg config.Config) *viper.Viper {
+	v := viper.New()
+	v.SetConfigFile(cfg.File)
+	err := v.ReadInConfig()
+	assert.NoError(t, err)
+	return v
+}
+
+func TestConfigureSet(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	cfg := loadSettingsTestConfig(t, `{"token": "abc123"}`)
+
+	err := runConfigureSet(cfg, "workspace", "~/exercism")
+	assert.NoError(t, err)
+	err = runConfigureSet(cfg, "test_commands.go", "go test -v ./...")
+	assert.NoError(t, err)
+
+	v := readSettingsTestConfig(t, cfg)
+	assert.Equal(t, filepath.Join(cfg.Home, "exercism"), v.GetString("workspace"))
+	assert.Equal(t, "go test -v ./...", v.GetString("test_commands.go"))
+	assert.Equal(t, "abc123", v.GetString("token"))
+}
+
+func TestConfigureSetInvalid(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	cfg := loadSettingsTestConfig(t, `{}`)
+
+	testCases := []struct {
+		key, value, message string
+	}{
+		{"autocommit", "always", "must be one of: workspace, track"},
+		{"apibaseurl", "localhost", "must be an http or https URL"},
+		{"colour", "blue", `unknown configuration key "colour"`},
+	}
+	for _, tc := range testCases {
+		err := runConfigureSet(cfg, tc.key, tc.value)
+		if assert.Error(t, err, tc.key) {
+			assert.Contains(t, err.Error(), tc.message)
+		}
+	}
+	v := readSettingsTestConfig(t, cfg)
+	assert.False(t, v.IsSet("autocommit"))
+	assert.False(t, v.IsSet("apibaseurl"))
+	assert.False(t, v.IsSet("colour"))
+}
@@ -71,7 +71,9 @@ func TestConfigureShow(t *testing.T) {
 	assert.Regexp(t, "configured.example", Err)
 	assert.NotRegexp(t, "override.example", Err)
 
-	assert.Regexp(t, "configured-token", Err)
+	// The token is redacted.
+	assert.Regexp(t, `conf\*+ken`, Err)
+	assert.NotRegexp(t, "configured-token", Err)
 	assert.NotRegexp(t, "token-override", Err)
 
 	assert.Regexp(t, "configured-workspace", Err)
@@ -408,6 +410,121 @@ func TestConfigureExplicitWorkspaceWithoutClobberingNonDirectory(t *testing.T) {
 	}
 }
 
+func TestConfigureAutocommit(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	testCases := []struct {
+		desc       string
+		configured string
+		args       []string
+		expected   string
+		err        bool
+	}{
+		{
+			desc:     "It commits per workspace",
+			args:     []string{"--no-verify", "--autocommit", "workspace"},
+			expected: "workspace",
+		},
+		{
+			desc:     "It commits per track",
+			args:     []string{"--no-verify", "--autocommit", "track"},
+			expected: "track",
+		},
+		{
+			desc:       "It doesn't lose a configured value",
+			configured: "track",
+			args:       []string{"--no-verify"},
+			expected:   "track",
+		},
+		{
+			desc:       "It turns auto-commits off",
+			configured: "track",
+			args:       []string{"--no-verify", "--autocommit", "off"},
+			expected:   "",
+		},
+		{
+			desc:       "It rejects an unknown scope",
+			configured: "track",
+			args:       []string{"--no-verify", "--autocommit", "exercise"},
+			expected:   "track",
+			err:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+		setupConfigureFlags(flags)
+
+		v := viper.New()
+		v.Set("token", "abc123")
+		v.Set("workspace", "/the-workspace")
+		v.Set("autocommit", tc.configured)
+
+		err := flags.Parse(tc.args)
+		assert.NoError(t, err)
+
+		cfg := config.Config{
+			Persister:       config.InMemoryPersister{},
+			UserViperConfig: v,
+			DefaultBaseURL:  "http://example.com",
+		}
+
+		err = runConfigure(cfg, flags)
+		if tc.err {
+			assert.Error(t, err, tc.desc)
+		} else {
+			assert.NoError(t, err, tc.desc)
+		}
+		assert.Equal(t, tc.expected, v.GetString("autocommit"), tc.desc)
+	}
+}
+
+func TestConfigureCredentialHelper(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	tmpDir, err := os.MkdirTemp("", "configure-credential-helper")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	helper := filepath.Join(tmpDir, "helper")
+	script := `#!/bin/sh
+if [ "$1" = store ]; then grep '^password=' > "$(dirname "$0")/store"; fi
+`
+	err = os.WriteFile(helper, []byte(script), os.FileMode(0755))
+	assert.NoError(t, err)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupConfigureFlags(flags)
+	err = flags.Parse([]string{"--no-verify", "--credential-helper", helper})
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("token", "plain-text-token")
+	v.Set("workspace", tmpDir)
+	cfg := config.Config{
+		Dir:             tmpDir,
+		Persister:       config.FilePersister{Dir: tmpDir},
+		UserViperConfig: v,
+		DefaultBaseURL:  "http://example.com",
+	}
+
+	err = runConfigure(cfg, flags)
+	assert.NoError(t, err)
+
+	stored, err := os.ReadFile(filepath.Join(tmpDir, "store"))
+	assert.NoError(t, err)
+	assert.Equal(t, "password=plain-text-token\n", string(stored))
+
+	// The token is no longer in the config file.
+	b, err := os.ReadFile(filepath.Join(tmpDir, "user.json"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "plain-text-token")
+	assert.Contains(t, string(b), helper)
+}
+
 func TestCommandifyFlagSet(t *testing.T) {
 	flags := pflag.NewFlagSet("primitives", pflag.PanicOnError)
 	flags.StringP("word", "w", "", "a word")
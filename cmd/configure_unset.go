@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/cobra"
+)
+
+// configureUnsetCmd removes a single configuration key.
+var configureUnsetCmd = &cobra.Command{
+	Use:   "unset <KEY>",
+	Short: "Remove a configuration key.",
+	Long: `Remove a configuration key from the config file.
+
+With a profile selected, the token, workspace and API base URL are removed
+from the profile, so that the values of the config file apply again.
+A value from a flag or environment variable still applies after this.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runConfigureUnset(cfg, args[0])
+	},
+}
+
+func runConfigureUnset(cfg config.Config, key string) error {
+	if !cfg.UserViperConfig.IsSet(key) {
+		return fmt.Errorf("%s is not set", key)
+	}
+	cfg.Unset(key)
+	return cfg.Save("user")
+}
+
+func init() {
+	configureCmd.AddCommand(configureUnsetCmd)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureUnset(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	cfg := loadSettingsTestConfig(t, `{"token": "abc123", "test_commands": {"go": "go test", "ruby": "rake"}}`)
+
+	err := runConfigureUnset(cfg, "test_commands.go")
+	assert.NoError(t, err)
+
+	v := readSettingsTestConfig(t, cfg)
+	assert.False(t, v.IsSet("test_commands.go"))
+	assert.Equal(t, "rake", v.GetString("test_commands.ruby"))
+	assert.Equal(t, "abc123", v.GetString("token"))
+
+	err = runConfigureUnset(cfg, "autocommit")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "autocommit is not set")
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// doctorCmd finds and repairs problems in the workspace.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Find and repair problems in your workspace.",
+	Long: `Find and repair problems in your workspace.
+
+The doctor checks every exercise in the workspace for:
+
+    - metadata files from old versions of the CLI (.solution.json)
+    - directories with a numeric suffix, such as two-fer-2
+    - directories that don't match the exercise slug in the metadata
+    - a missing .exercism/config.json
+    - files and directories that you can't read or write
+    - directories in users/ that don't hold any exercises
+
+It reports every problem it finds. Use --fix to apply the repairs
+that are safe to make automatically; the others need your attention.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runDoctor(cfg, cmd.Flags(), args)
+	},
+}
+
+func runDoctor(cfg config.Config, flags *pflag.FlagSet, args []string) error {
+	usrCfg := cfg.UserViperConfig
+	if usrCfg.GetString("workspace") == "" {
+		return fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	fix, err := flags.GetBool("fix")
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return err
+	}
+	problems, err := ws.Diagnose()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Fprintf(Out, "No problems found in %s\n", ws.Dir)
+		return nil
+	}
+
+	var fixed, fixable int
+	for _, problem := range problems {
+		fmt.Fprintf(Out, "%s: %s\n    %s\n", problem.Kind, problem.Path, problem.Description)
+		switch {
+		case !problem.Fixable():
+			fmt.Fprintf(Out, "    This needs to be repaired by hand.\n")
+		case !fix:
+			fixable++
+			fmt.Fprintf(Out, "    Can be repaired with --fix: %s\n", problem.Repair)
+		default:
+			if err := problem.Fix(); err != nil {
+				fmt.Fprintf(Out, "    Failed to %s: %s\n", problem.Repair, err)
+				continue
+			}
+			fixed++
+			fmt.Fprintf(Out, "    Repaired: %s\n", problem.Repair)
+		}
+	}
+
+	remaining := len(problems) - fixed
+	fmt.Fprintf(Out, "\n%d problems found, %d repaired\n", len(problems), fixed)
+	if remaining == 0 {
+		return nil
+	}
+	if fixable > 0 {
+		return fmt.Errorf("%d problems remain, run `%s doctor --fix` to repair %d of them", remaining, BinaryName, fixable)
+	}
+	return fmt.Errorf("%d problems remain", remaining)
+}
+
+func setupDoctorFlags(flags *pflag.FlagSet) {
+	flags.BoolP("fix", "", false, "apply the repairs that are safe to make automatically")
+}
+
+func init() {
+	RootCmd.AddCommand(doctorCmd)
+	setupDoctorFlags(doctorCmd.Flags())
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctor(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	tmpDir, cfg := setupTestWorkspace(t)
+	dir := filepath.Join(tmpDir, "bogus-track", "bogus-exercise-2")
+	writeTestExercise(t, dir, workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+	err := os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{}`), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	Out = &bytes.Buffer{}
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupDoctorFlags(flags)
+	err = runDoctor(cfg, flags, []string{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, "doctor --fix", err.Error())
+	}
+	assert.Regexp(t, "numeric suffix: .*bogus-exercise-2", Out.(*bytes.Buffer).String())
+	assert.DirExists(t, dir)
+
+	Out = &bytes.Buffer{}
+	err = flags.Parse([]string{"--fix"})
+	assert.NoError(t, err)
+	err = runDoctor(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.Contains(t, Out.(*bytes.Buffer).String(), "1 problems found, 1 repaired")
+	assert.DirExists(t, filepath.Join(tmpDir, "bogus-track", "bogus-exercise"))
+
+	Out = &bytes.Buffer{}
+	err = runDoctor(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.Contains(t, Out.(*bytes.Buffer).String(), "No problems found")
+}
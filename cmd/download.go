@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/exercism/cli/api"
 	"github.com/exercism/cli/config"
@@ -35,15 +36,13 @@ latest solution.
 Download other people's solutions by providing the UUID.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.NewConfig()
-
-		v := viper.New()
-		v.AddConfigPath(cfg.Dir)
-		v.SetConfigName("user")
-		v.SetConfigType("json")
-		// Ignore error. If the file doesn't exist, that is fine.
-		_ = v.ReadInConfig()
-		cfg.UserViperConfig = v
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		if err := cfg.LoadCredentials(); err != nil {
+			return err
+		}
 
 		return runDownload(cfg, cmd.Flags(), args)
 	},
@@ -51,7 +50,7 @@ Download other people's solutions by providing the UUID.
 
 func runDownload(cfg config.Config, flags *pflag.FlagSet, args []string) error {
 	usrCfg := cfg.UserViperConfig
-	if err := validateUserConfig(usrCfg); err != nil {
+	if err := validateUserConfig(cfg); err != nil {
 		return err
 	}
 
@@ -61,11 +60,17 @@ func runDownload(cfg config.Config, flags *pflag.FlagSet, args []string) error {
 	}
 
 	metadata := download.payload.metadata()
+	downloadedAt := time.Now().UTC().Truncate(time.Second)
+	metadata.DownloadedAt = &downloadedAt
 	dir := metadata.Exercise(usrCfg.GetString("workspace")).MetadataDir()
 
 	if _, err = os.Stat(dir); !download.forceoverwrite && err == nil {
 		return fmt.Errorf("directory '%s' already exists, use --force to overwrite", dir)
 	}
+	// An exercise that is downloaded again keeps its count of submissions from this machine.
+	if existing, err := workspace.NewExerciseMetadata(dir); err == nil && existing.ID == metadata.ID {
+		metadata.Iteration = existing.Iteration
+	}
 
 	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
 		return err
@@ -80,6 +85,7 @@ func runDownload(cfg config.Config, flags *pflag.FlagSet, args []string) error {
 		return err
 	}
 
+	var downloaded []string
 	for _, sf := range download.payload.files() {
 		url, err := sf.url()
 		if err != nil {
@@ -117,7 +123,19 @@ func runDownload(cfg config.Config, flags *pflag.FlagSet, args []string) error {
 		if err != nil {
 			return err
 		}
+		downloaded = append(downloaded, strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/"))
+	}
+
+	// Record the downloaded state, to tell later which files have been modified.
+	if err := metadata.RecordChecksums(downloaded); err != nil {
+		return err
+	}
+	if err := metadata.Write(metadata.Dir); err != nil {
+		return err
 	}
+
+	autocommit(usrCfg, &metadata, "Download")
+
 	fmt.Fprintf(Err, "\nDownloaded to\n")
 	fmt.Fprintf(Out, "%s\n", metadata.Dir)
 	return nil
@@ -288,6 +306,13 @@ type downloadPayload struct {
 }
 
 func (dp downloadPayload) metadata() workspace.ExerciseMetadata {
+	var submittedAt *time.Time
+	if dp.Solution.Iteration.SubmittedAt != nil {
+		// The API may send the timestamp with a lowercase "t" and "z".
+		if t, err := time.Parse(time.RFC3339, strings.ToUpper(*dp.Solution.Iteration.SubmittedAt)); err == nil {
+			submittedAt = &t
+		}
+	}
 	return workspace.ExerciseMetadata{
 		AutoApprove:  dp.Solution.Exercise.AutoApprove,
 		Track:        dp.Solution.Exercise.Track.ID,
@@ -296,6 +321,7 @@ func (dp downloadPayload) metadata() workspace.ExerciseMetadata {
 		URL:          dp.Solution.URL,
 		Handle:       dp.Solution.User.Handle,
 		IsRequester:  dp.Solution.User.IsRequester,
+		SubmittedAt:  submittedAt,
 	}
 }
 
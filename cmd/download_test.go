@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/exercism/cli/config"
 	"github.com/exercism/cli/workspace"
@@ -200,6 +201,14 @@ func TestDownload(t *testing.T) {
 		assert.Equal(t, "bogus-track", metadata.Track)
 		assert.Equal(t, "bogus-exercise", metadata.ExerciseSlug)
 		assert.Equal(t, tc.requester, metadata.IsRequester)
+		if assert.NotNil(t, metadata.SubmittedAt) {
+			assert.Equal(t, 2017, metadata.SubmittedAt.Year())
+		}
+		if assert.NotNil(t, metadata.DownloadedAt) {
+			assert.WithinDuration(t, time.Now(), *metadata.DownloadedAt, time.Minute)
+		}
+		assert.Len(t, metadata.Checksums, 3)
+		assert.Contains(t, metadata.Checksums, "subdir/file-2.txt")
 	}
 }
 
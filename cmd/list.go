@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// listCmd lists the exercises in the workspace.
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the exercises in your workspace.",
+	Long: `List the exercises in your workspace.
+
+This includes your own exercises as well as other people's solutions
+that you downloaded, which live in users/<handle> within the workspace.
+
+Use --track and --author to filter the list, and --sort to order it by
+track, exercise, author or submitted (most recent first). Use --json for
+output that is easy to process in scripts.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runList(cfg, cmd.Flags(), args)
+	},
+}
+
+// listedExercise is an exercise as reported by the list command.
+type listedExercise struct {
+	Track       string     `json:"track"`
+	Slug        string     `json:"slug"`
+	Handle      string     `json:"handle"`
+	IsRequester bool       `json:"is_requester"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	URL         string     `json:"url"`
+	Path        string     `json:"path"`
+}
+
+// author is "you" for the user's own exercises, and the handle of the person otherwise.
+func (e listedExercise) author() string {
+	if e.IsRequester {
+		return "you"
+	}
+	return "@" + e.Handle
+}
+
+// listSorts order the listed exercises.
+var listSorts = map[string]func(a, b listedExercise) bool{
+	"track":     lessByTrack,
+	"exercise":  lessByExercise,
+	"author":    lessByAuthor,
+	"submitted": lessBySubmitted,
+}
+
+func lessByTrack(a, b listedExercise) bool {
+	if a.Track != b.Track {
+		return a.Track < b.Track
+	}
+	return lessByExercise(a, b)
+}
+
+func lessByExercise(a, b listedExercise) bool {
+	if a.Slug != b.Slug {
+		return a.Slug < b.Slug
+	}
+	return lessByAuthor(a, b)
+}
+
+// lessByAuthor puts the user's own exercises first.
+func lessByAuthor(a, b listedExercise) bool {
+	if a.IsRequester != b.IsRequester {
+		return a.IsRequester
+	}
+	if a.Handle != b.Handle {
+		return a.Handle < b.Handle
+	}
+	if a.Track != b.Track {
+		return a.Track < b.Track
+	}
+	return a.Slug < b.Slug
+}
+
+// lessBySubmitted puts the most recent submissions first, and unsubmitted exercises last.
+func lessBySubmitted(a, b listedExercise) bool {
+	switch {
+	case a.SubmittedAt == nil && b.SubmittedAt == nil:
+	case a.SubmittedAt == nil:
+		return false
+	case b.SubmittedAt == nil:
+		return true
+	case !a.SubmittedAt.Equal(*b.SubmittedAt):
+		return a.SubmittedAt.After(*b.SubmittedAt)
+	}
+	return lessByTrack(a, b)
+}
+
+func runList(cfg config.Config, flags *pflag.FlagSet, args []string) error {
+	usrCfg := cfg.UserViperConfig
+	if usrCfg.GetString("workspace") == "" {
+		return fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	track, err := flags.GetString("track")
+	if err != nil {
+		return err
+	}
+	author, err := flags.GetString("author")
+	if err != nil {
+		return err
+	}
+	sortBy, err := flags.GetString("sort")
+	if err != nil {
+		return err
+	}
+	less, ok := listSorts[sortBy]
+	if !ok {
+		return fmt.Errorf("can't sort by %q, use one of track, exercise, author or submitted", sortBy)
+	}
+	asJSON, err := flags.GetBool("json")
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return err
+	}
+	own, err := ws.Exercises()
+	if err != nil {
+		return err
+	}
+	community, err := ws.CommunityExercises()
+	if err != nil {
+		return err
+	}
+
+	listed := []listedExercise{}
+	for _, exercise := range append(own, community...) {
+		metadata, err := workspace.NewExerciseMetadata(exercise.Filepath())
+		if err != nil {
+			return err
+		}
+		e := listedExercise{
+			Track:       exercise.Track,
+			Slug:        exercise.Slug,
+			Handle:      metadata.Handle,
+			IsRequester: metadata.IsRequester,
+			SubmittedAt: metadata.SubmittedAt,
+			URL:         metadata.URL,
+			Path:        exercise.Filepath(),
+		}
+		if track != "" && e.Track != track {
+			continue
+		}
+		if author != "" && e.Handle != author {
+			continue
+		}
+		listed = append(listed, e)
+	}
+	sort.SliceStable(listed, func(i, j int) bool {
+		return less(listed[i], listed[j])
+	})
+
+	if asJSON {
+		enc := json.NewEncoder(Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(listed)
+	}
+
+	if len(listed) == 0 {
+		fmt.Fprintln(Err, "No exercises found.")
+		return nil
+	}
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TRACK\tEXERCISE\tAUTHOR\tSUBMITTED\tURL")
+	for _, e := range listed {
+		submitted := "-"
+		if e.SubmittedAt != nil {
+			submitted = e.SubmittedAt.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Track, e.Slug, e.author(), submitted, e.URL)
+	}
+	return w.Flush()
+}
+
+func setupListFlags(flags *pflag.FlagSet) {
+	flags.StringP("track", "t", "", "only list the exercises of this track")
+	flags.StringP("author", "a", "", "only list the exercises by the person with this handle")
+	flags.StringP("sort", "s", "track", "sort by track, exercise, author or submitted")
+	flags.BoolP("json", "", false, "output the list as JSON")
+}
+
+func init() {
+	RootCmd.AddCommand(listCmd)
+	setupListFlags(listCmd.Flags())
+}
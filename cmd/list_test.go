@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupListTest(t *testing.T) (string, config.Config) {
+	older := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	newer := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	return setupTestWorkspace(t,
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "two-fer", Handle: "me", IsRequester: true, SubmittedAt: &older, URL: "http://example.com/go/two-fer"},
+		workspace.ExerciseMetadata{Track: "rust", ExerciseSlug: "leap", Handle: "me", IsRequester: true},
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", Handle: "alice", SubmittedAt: &newer, URL: "http://example.com/alice/go/leap"},
+	)
+}
+
+func TestList(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	_, cfg := setupListTest(t)
+
+	testCases := []struct {
+		desc     string
+		args     []string
+		expected []string
+	}{
+		{
+			desc:     "sorted by track by default",
+			args:     []string{},
+			expected: []string{"go/leap@alice", "go/two-fer@me", "rust/leap@me"},
+		},
+		{
+			desc:     "sorted by most recent submission",
+			args:     []string{"--sort", "submitted"},
+			expected: []string{"go/leap@alice", "go/two-fer@me", "rust/leap@me"},
+		},
+		{
+			desc:     "sorted by author",
+			args:     []string{"--sort", "author"},
+			expected: []string{"go/two-fer@me", "rust/leap@me", "go/leap@alice"},
+		},
+		{
+			desc:     "filtered by track",
+			args:     []string{"--track", "rust"},
+			expected: []string{"rust/leap@me"},
+		},
+		{
+			desc:     "filtered by author",
+			args:     []string{"--author", "alice"},
+			expected: []string{"go/leap@alice"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			Out = &bytes.Buffer{}
+			flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+			setupListFlags(flags)
+			err := flags.Parse(append(tc.args, "--json"))
+			assert.NoError(t, err)
+
+			err = runList(cfg, flags, []string{})
+			assert.NoError(t, err)
+
+			var listed []listedExercise
+			err = json.Unmarshal(Out.(*bytes.Buffer).Bytes(), &listed)
+			assert.NoError(t, err)
+			actual := make([]string, 0, len(listed))
+			for _, e := range listed {
+				actual = append(actual, e.Track+"/"+e.Slug+"@"+e.Handle)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestListTable(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	_, cfg := setupListTest(t)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupListFlags(flags)
+	err := runList(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	out := Out.(*bytes.Buffer).String()
+	assert.Regexp(t, `go\s+leap\s+@alice\s+2022-01-0\d \d\d:\d\d\s+http://example.com/alice/go/leap`, out)
+	assert.Regexp(t, `rust\s+leap\s+you\s+-`, out)
+}
+
+func TestListInvalidSort(t *testing.T) {
+	v := viper.New()
+	v.Set("workspace", "/home/username")
+	cfg := config.Config{UserViperConfig: v}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupListFlags(flags)
+	err := flags.Parse([]string{"--sort", "size"})
+	assert.NoError(t, err)
+
+	err = runList(cfg, flags, []string{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, `can't sort by "size"`, err.Error())
+	}
+}
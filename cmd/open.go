@@ -8,12 +8,15 @@ import (
 
 // openCmd opens the designated exercise in the browser.
 var openCmd = &cobra.Command{
-	Use:     "open",
+	Use:     "open [<PATH> | <EXERCISE>]",
 	Aliases: []string{"o"},
 	Short:   "Open an exercise on the website.",
 	Long: `Open the specified exercise to the solution page on the Exercism website.
 
 Pass the path to the directory that contains the solution you want to see on the website.
+Without a path, it opens the exercise that contains the current directory.
+You can also name the exercise, e.g. "go/two-fer", or just "two-fer" if there
+is only one exercise with that slug in your workspace.
 	`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -21,7 +24,26 @@ Pass the path to the directory that contains the solution you want to see on the
 		if len(args) == 1 {
 			path = args[0]
 		}
-		metadata, err := workspace.NewExerciseMetadata(path)
+		if pathType, _ := workspace.DetectPathType(path); pathType == workspace.TypeExerciseID {
+			cfg, err := loadConfig(cmd.Flags(), false)
+			if err != nil {
+				return err
+			}
+
+			exerciseDir, err := findExerciseByID(cfg.UserViperConfig, path)
+			if err != nil {
+				return err
+			}
+			if exerciseDir == "" {
+				return exerciseNotFoundError(path)
+			}
+			path = exerciseDir
+		}
+		dir, err := findExerciseDir(path)
+		if err != nil {
+			return err
+		}
+		metadata, err := workspace.NewExerciseMetadata(dir)
 		if err != nil {
 			return err
 		}
@@ -1,19 +1,172 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/tabwriter"
 
-// prepareCmd does necessary setup for Exercism and its tracks.
+	"github.com/exercism/cli/api"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// prepareCmd gets the workspace and the tools ready for a track.
 var prepareCmd = &cobra.Command{
 	Use:     "prepare",
 	Aliases: []string{"p"},
-	Short:   "Prepare does setup for Exercism and its tracks.",
-	Long: `Prepare downloads settings and dependencies for Exercism and the language tracks.
-	`,
+	Short:   "Prepare gets you ready to start on a track.",
+	Long: `Prepare gets your workspace and tools ready to start on a track.
+
+Run it once before starting on a track:
+
+    exercism prepare --track=go
+
+It checks with the API that the track exists, creates the track's
+directory in the workspace, and checks that the tools needed to run
+the track's tests are installed.
+
+It finishes with a report of what it did, and fails if the track
+isn't ready to run tests yet.
+`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return nil
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		if err := cfg.LoadCredentials(); err != nil {
+			return err
+		}
+
+		return runPrepare(cfg, cmd.Flags(), args)
 	},
 }
 
+func runPrepare(cfg config.Config, flags *pflag.FlagSet, args []string) error {
+	usrCfg := cfg.UserViperConfig
+	if err := validateUserConfig(cfg); err != nil {
+		return err
+	}
+
+	track, err := flags.GetString("track")
+	if err != nil {
+		return err
+	}
+	if track == "" {
+		return errors.New("need a --track to prepare")
+	}
+	if filepath.Base(track) != track || track == "." || track == ".." {
+		return fmt.Errorf("invalid track ID %q", track)
+	}
+
+	payload, err := getPrepareTrackPayload(usrCfg, track)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(usrCfg.GetString("workspace"), track)
+	dirStatus := "exists"
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		dirStatus = "created"
+	}
+	if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	toolchain, supported := prepareToolchain(usrCfg, track, dir)
+	problems := toolchain.Check()
+
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Track\t%s (%s)\n", payload.Track.Language, track)
+	fmt.Fprintf(w, "Directory\t%s (%s)\n", dir, dirStatus)
+	if !supported {
+		fmt.Fprintf(w, "Toolchain\tunknown, the CLI can't run this track's tests\n")
+	}
+	for _, tool := range toolchain.Tools {
+		status := fmt.Sprintf("%s (found)", tool.Name)
+		for _, problem := range problems {
+			if problem.Tool.Name == tool.Name {
+				status = problem.String()
+			}
+		}
+		fmt.Fprintf(w, "Toolchain\t%s\n", status)
+	}
+	w.Flush()
+
+	if len(problems) > 0 {
+		return missingToolsError(track, problems)
+	}
+	fmt.Fprintf(Out, "\nThe %s track is ready.\n", track)
+	return nil
+}
+
+func getPrepareTrackPayload(usrCfg *viper.Viper, track string) (*prepareTrackPayload, error) {
+	client, err := api.NewClient(usrCfg.GetString("token"), usrCfg.GetString("apibaseurl"))
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/tracks/%s", usrCfg.GetString("apibaseurl"), track)
+	req, err := client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, decodedAPIError(res)
+	}
+
+	var payload prepareTrackPayload
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("unable to parse API response - %s", err)
+	}
+	return &payload, nil
+}
+
+// prepareToolchain determines what the track's tests need.
+// The second return value is false if the CLI doesn't know how to run the track's tests.
+func prepareToolchain(usrCfg *viper.Viper, track, dir string) (workspace.Toolchain, bool) {
+	testConf, ok := workspace.LookupTestConfiguration(dir, track, "", usrCfg.GetStringMapString("test_commands"))
+	if !ok {
+		return workspace.Toolchain{}, false
+	}
+	command := testConf.Command
+	if runtime.GOOS == "windows" && testConf.WindowsCommand != "" {
+		command = testConf.WindowsCommand
+	}
+	// Without an exercise, a templated executable can't be known.
+	var executable string
+	if fields := strings.Fields(command); len(fields) > 0 && !strings.Contains(fields[0], "{{") {
+		executable = fields[0]
+	}
+	return workspace.LookupToolchain(track, testConf, executable), true
+}
+
+type prepareTrackPayload struct {
+	Track struct {
+		ID       string `json:"id"`
+		Language string `json:"language"`
+	} `json:"track"`
+}
+
+func setupPrepareFlags(flags *pflag.FlagSet) {
+	flags.StringP("track", "t", "", "the track ID")
+}
+
 func init() {
 	RootCmd.AddCommand(prepareCmd)
+	setupPrepareFlags(prepareCmd.Flags())
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareWithoutTrack(t *testing.T) {
+	v := viper.New()
+	v.Set("token", "abc123")
+	v.Set("workspace", "/home/username")
+	v.Set("apibaseurl", "http://example.com")
+	cfg := config.Config{UserViperConfig: v}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupPrepareFlags(flags)
+
+	err := runPrepare(cfg, flags, []string{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, "need a --track", err.Error())
+	}
+}
+
+func fakePrepareServer() *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/tracks/bogus-track", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"track": {
+				"id": "bogus-track",
+				"language": "Bogus Language",
+				"test_pattern": "_test"
+			}
+		}`)
+	})
+	return server
+}
+
+func TestPrepare(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	ts := fakePrepareServer()
+	defer ts.Close()
+
+	tmpDir, err := os.MkdirTemp("", "prepare-cmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	workspaceDir := filepath.Join(tmpDir, "workspace")
+	configDir := filepath.Join(tmpDir, "config")
+
+	v := viper.New()
+	v.Set("token", "abc123")
+	v.Set("workspace", workspaceDir)
+	v.Set("apibaseurl", ts.URL)
+	v.Set("test_commands", map[string]string{"bogus-track": "go test"})
+	cfg := config.Config{
+		Dir:             configDir,
+		UserViperConfig: v,
+		Persister:       config.FilePersister{Dir: configDir},
+	}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupPrepareFlags(flags)
+	err = flags.Set("track", "bogus-track")
+	assert.NoError(t, err)
+
+	err = runPrepare(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	out := Out.(*bytes.Buffer).String()
+	assert.Regexp(t, `Track\s+Bogus Language \(bogus-track\)`, out)
+	assert.Regexp(t, `Directory\s+.*bogus-track \(created\)`, out)
+	assert.Regexp(t, `Toolchain\s+go \(found\)`, out)
+	assert.Contains(t, out, "The bogus-track track is ready.")
+	assert.DirExists(t, filepath.Join(workspaceDir, "bogus-track"))
+	assert.NoFileExists(t, filepath.Join(configDir, "cli.json"))
+}
+
+func TestPrepareWithMissingTools(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	ts := fakePrepareServer()
+	defer ts.Close()
+
+	tmpDir, err := os.MkdirTemp("", "prepare-cmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	v := viper.New()
+	v.Set("token", "abc123")
+	v.Set("workspace", tmpDir)
+	v.Set("apibaseurl", ts.URL)
+	v.Set("test_commands", map[string]string{"bogus-track": "no-such-test-runner --verbose"})
+	cfg := config.Config{
+		Dir:             tmpDir,
+		UserViperConfig: v,
+		Persister:       config.InMemoryPersister{},
+	}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupPrepareFlags(flags)
+	err = flags.Set("track", "bogus-track")
+	assert.NoError(t, err)
+
+	err = runPrepare(cfg, flags, []string{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, "requires tools that are not installed", err.Error())
+	}
+	assert.Regexp(t, `Toolchain\s+no-such-test-runner \(not found\)`, Out.(*bytes.Buffer).String())
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// profilesCmd lists the configuration profiles.
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List your configuration profiles.",
+	Long: `List your configuration profiles, marking the active one.
+
+A profile is a named set of token, API base URL and workspace, e.g. for
+a second account, or a local or staging server. Create or update one with
+
+    exercism configure --profile=NAME --token=TOKEN --api=URL
+
+Select a profile with the --profile flag, or the EXERCISM_PROFILE environment
+variable. The "default" profile is the configuration without a profile.
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// A missing active profile is reported after the list.
+		cfg, err := loadConfig(cmd.Flags(), true)
+		if err != nil {
+			return err
+		}
+
+		// The profiles are shown as configured, without overrides from flags and the environment.
+		v := viper.New()
+		v.SetConfigFile(cfg.File)
+		v.SetConfigType("json")
+		// Ignore error. If the file doesn't exist, that is fine.
+		_ = v.ReadInConfig()
+		cfg.UserViperConfig = v
+
+		return runProfiles(cfg)
+	},
+}
+
+func runProfiles(cfg config.Config) error {
+	names, err := config.ProfileNames(cfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tPROFILE\tAPI BASE URL\tWORKSPACE")
+	printProfile(w, config.DefaultProfile, cfg.UserViperConfig, cfg.Profile == "")
+	found := cfg.Profile == ""
+	for _, name := range names {
+		v, err := config.ReadProfile(cfg.Dir, name)
+		if err != nil {
+			return err
+		}
+		// Values missing from the profile come from the user config.
+		for _, key := range config.ProfileKeys {
+			if !v.IsSet(key) {
+				v.Set(key, cfg.UserViperConfig.Get(key))
+			}
+		}
+		printProfile(w, name, v, name == cfg.Profile)
+		found = found || name == cfg.Profile
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("the active profile %q doesn't exist, create it by running configure with --profile=%[1]s", cfg.Profile)
+	}
+	return nil
+}
+
+func printProfile(w *tabwriter.Writer, name string, v *viper.Viper, active bool) {
+	marker := ""
+	if active {
+		marker = "*"
+	}
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, name, v.GetString("apibaseurl"), v.GetString("workspace"))
+}
+
+func init() {
+	RootCmd.AddCommand(profilesCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiles(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	dir, err := os.MkdirTemp("", "profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	err = os.MkdirAll(filepath.Join(dir, "profiles"), os.FileMode(0700))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "profiles", "staging.json"), []byte(`{"apibaseurl": "http://localhost:3000/api/v1"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("apibaseurl", "https://api.exercism.org/v1")
+	v.Set("workspace", "/workspace")
+	cfg := config.Config{Dir: dir, UserViperConfig: v, Profile: "staging"}
+
+	err = runProfiles(cfg)
+	assert.NoError(t, err)
+
+	expected := `   PROFILE  API BASE URL                  WORKSPACE
+   default  https://api.exercism.org/v1   /workspace
+*  staging  http://localhost:3000/api/v1  /workspace
+`
+	assert.Equal(t, expected, Out.(*bytes.Buffer).String())
+}
+
+func TestProfilesWithMissingActiveProfile(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	dir, err := os.MkdirTemp("", "profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := config.Config{Dir: dir, UserViperConfig: viper.New(), Profile: "missing"}
+	err = runProfiles(cfg)
+	if assert.Error(t, err) {
+		assert.Regexp(t, `profile "missing" doesn't exist`, err.Error())
+	}
+}
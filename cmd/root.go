@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -9,7 +10,9 @@ import (
 	"github.com/exercism/cli/cli"
 	"github.com/exercism/cli/config"
 	"github.com/exercism/cli/debug"
+	"github.com/exercism/cli/transport"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // RootCmd represents the base command when called without any subcommands.
@@ -34,6 +37,55 @@ Download exercises and submit your solutions.`,
 	},
 }
 
+// setupHTTPClients gives the api and cli packages clients on a transport configured from the user config,
+// e.g. with a proxy or an extra CA bundle. A broken setting is only a warning, so that it can be fixed with configure.
+func setupHTTPClients(cfg config.Config) {
+	settings := transport.FromConfig(cfg.UserViperConfig)
+	apiClient, err := settings.NewClient(api.TimeoutInSeconds)
+	if err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Ignoring the network settings: %s\n", err)
+		return
+	}
+	cliClient, err := settings.NewClient(cli.TimeoutInSeconds)
+	if err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Ignoring the network settings: %s\n", err)
+		return
+	}
+	api.HTTPClient = apiClient
+	cli.HTTPClient = cliClient
+}
+
+// loadConfig loads the user config, see config.Load, and sets up the HTTP clients with its network settings.
+// Commands load the config once with it, and pass it on.
+func loadConfig(flags *pflag.FlagSet, createProfile bool) (config.Config, error) {
+	cfg, err := config.Load(flags, createProfile)
+	var tooNew *config.ErrConfigTooNew
+	if errors.As(err, &tooNew) {
+		msg := `
+
+    The config file %s was written by a newer version of the CLI (format version %d).
+    This version of the CLI only understands format version %d or older.
+
+    Run '%s upgrade' to get the latest version.
+
+`
+		return cfg, fmt.Errorf(msg, tooNew.File, tooNew.Version, tooNew.Supported, BinaryName)
+	}
+	if err != nil {
+		return cfg, err
+	}
+	setupHTTPClients(cfg)
+	return cfg, nil
+}
+
+// setupNetwork sets up the HTTP clients for commands that need nothing else from the user config.
+// They still work with the default clients if the config can't be loaded.
+func setupNetwork(flags *pflag.FlagSet) {
+	if cfg, err := config.Load(flags, false); err == nil {
+		setupHTTPClients(cfg)
+	}
+}
+
 // Execute adds all child commands to the root command.
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
@@ -50,8 +102,13 @@ func init() {
 	config.SetDefaultDirName(BinaryName)
 	Out = os.Stdout
 	Err = os.Stderr
+	In = os.Stdin
 	api.UserAgent = fmt.Sprintf("github.com/exercism/cli v%s (%s/%s)", Version, runtime.GOOS, runtime.GOARCH)
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
 	RootCmd.PersistentFlags().IntP("timeout", "", 0, "override the default HTTP timeout (seconds)")
+	RootCmd.PersistentFlags().StringP("config", "", "", "the user config file to use, instead of user.json in the config dir")
+	RootCmd.PersistentFlags().StringP("profile", "", "", "the configuration profile to use, overriding EXERCISM_PROFILE")
+	RootCmd.PersistentFlags().StringP("workspace", "", "", "the workspace to use, overriding EXERCISM_WORKSPACE and the config")
+	RootCmd.PersistentFlags().StringP("api", "", "", "the API base URL to use, overriding EXERCISM_API_BASE_URL and the config")
 	RootCmd.PersistentFlags().BoolP("unmask-token", "", false, "will unmask the API during a request/response dump")
 }
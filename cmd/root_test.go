@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigWrittenByNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("EXERCISM_CONFIG_HOME", dir)
+	t.Setenv(config.ProfileEnvVar, "")
+	err := os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"version": 99}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	originalBinaryName := BinaryName
+	BinaryName = "my-exercism"
+	defer func() { BinaryName = originalBinaryName }()
+
+	_, err = loadConfig(nil, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "written by a newer version of the CLI (format version 99)")
+		assert.Contains(t, err.Error(), "Run 'my-exercism upgrade' to get the latest version.")
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// statusCmd reports which exercises have changed since they were last submitted.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which exercises have unsubmitted changes.",
+	Long: `Show which exercises have unsubmitted changes.
+
+The files of each exercise in the workspace are compared to the state
+they were in when they were last submitted or downloaded. The status is one of:
+
+    clean            nothing changed since the last submit
+    modified         files changed since the last submit
+    never submitted  no solution has been submitted yet
+    missing files    files of the exercise were deleted
+    unknown          the exercise was downloaded by an older version of the CLI
+
+Use --track to only show the exercises of one track, and --json for
+output that is easy to process in scripts.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runStatus(cfg, cmd.Flags(), args)
+	},
+}
+
+// exerciseStatus is the status of one exercise, as reported by the status command.
+type exerciseStatus struct {
+	Exercise    string                   `json:"exercise"`
+	Track       string                   `json:"track"`
+	Slug        string                   `json:"slug"`
+	Path        string                   `json:"path"`
+	Status      workspace.ExerciseStatus `json:"status"`
+	Files       []string                 `json:"files,omitempty"`
+	SubmittedAt *time.Time               `json:"submitted_at,omitempty"`
+}
+
+func runStatus(cfg config.Config, flags *pflag.FlagSet, args []string) error {
+	usrCfg := cfg.UserViperConfig
+	if usrCfg.GetString("workspace") == "" {
+		return fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	track, err := flags.GetString("track")
+	if err != nil {
+		return err
+	}
+	asJSON, err := flags.GetBool("json")
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return err
+	}
+	exercises, err := ws.Exercises()
+	if err != nil {
+		return err
+	}
+
+	statuses := []exerciseStatus{}
+	for _, exercise := range exercises {
+		if track != "" && exercise.Track != track {
+			continue
+		}
+		metadata, err := workspace.NewExerciseMetadata(exercise.Filepath())
+		if err != nil {
+			return err
+		}
+		status, files, err := metadata.Status()
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, exerciseStatus{
+			Exercise:    exercise.Path(),
+			Track:       exercise.Track,
+			Slug:        exercise.Slug,
+			Path:        exercise.Filepath(),
+			Status:      status,
+			Files:       files,
+			SubmittedAt: metadata.SubmittedAt,
+		})
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+
+	if len(statuses) == 0 {
+		return errors.New("no exercises found in the workspace")
+	}
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EXERCISE\tSTATUS\tFILES")
+	for _, status := range statuses {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", status.Exercise, status.Status, strings.Join(status.Files, " "))
+	}
+	return w.Flush()
+}
+
+func setupStatusFlags(flags *pflag.FlagSet) {
+	flags.StringP("track", "t", "", "only show the exercises of this track")
+	flags.BoolP("json", "", false, "output the status as JSON")
+}
+
+func init() {
+	RootCmd.AddCommand(statusCmd)
+	setupStatusFlags(statusCmd.Flags())
+}
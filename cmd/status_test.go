@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatus(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	tmpDir, err := os.MkdirTemp("", "status-cmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	submittedAt := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, em := range []*workspace.ExerciseMetadata{
+		{Track: "bogus-track", ExerciseSlug: "clean", IsRequester: true, SubmittedAt: &submittedAt},
+		{Track: "bogus-track", ExerciseSlug: "modified", IsRequester: true, SubmittedAt: &submittedAt},
+		{Track: "other-track", ExerciseSlug: "fresh", IsRequester: true},
+	} {
+		dir := filepath.Join(tmpDir, em.Track, em.ExerciseSlug)
+		err := os.MkdirAll(dir, os.FileMode(0755))
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dir, "solution.txt"), []byte("solution"), os.FileMode(0644))
+		assert.NoError(t, err)
+		em.Dir = dir
+		err = em.RecordChecksums([]string{"solution.txt"})
+		assert.NoError(t, err)
+		err = em.Write(dir)
+		assert.NoError(t, err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, "bogus-track", "modified", "solution.txt"), []byte("changed"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	cfg := config.Config{UserViperConfig: v}
+
+	Out = &bytes.Buffer{}
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupStatusFlags(flags)
+	err = runStatus(cfg, flags, []string{})
+	assert.NoError(t, err)
+	out := Out.(*bytes.Buffer).String()
+	assert.Regexp(t, `bogus-track/clean\s+clean`, out)
+	assert.Regexp(t, `bogus-track/modified\s+modified\s+solution.txt`, out)
+	assert.Regexp(t, `other-track/fresh\s+never submitted`, out)
+
+	Out = &bytes.Buffer{}
+	flags = pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupStatusFlags(flags)
+	err = flags.Parse([]string{"--track", "bogus-track", "--json"})
+	assert.NoError(t, err)
+	err = runStatus(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	var statuses []map[string]interface{}
+	err = json.Unmarshal(Out.(*bytes.Buffer).Bytes(), &statuses)
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 2) {
+		assert.Equal(t, "bogus-track/clean", statuses[0]["exercise"])
+		assert.Equal(t, "clean", statuses[0]["status"])
+		assert.Equal(t, "modified", statuses[1]["status"])
+		assert.Equal(t, []interface{}{"solution.txt"}, statuses[1]["files"])
+	}
+}
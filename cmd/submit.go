@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/exercism/cli/api"
 	"github.com/exercism/cli/config"
@@ -19,25 +20,30 @@ import (
 
 // submitCmd lets people upload a solution to the website.
 var submitCmd = &cobra.Command{
-	Use:     "submit [<FILE> ...]",
+	Use:     "submit [<FILE> ... | <EXERCISE>]",
 	Aliases: []string{"s"},
 	Short:   "Submit your solution to an exercise.",
 	Long: `Submit your solution to an Exercism exercise.
 
     Call the command with the list of files you want to submit.
     If you omit the list of files, the CLI will submit the
-    default solution files for the exercise.
+    default solution files for the exercise you are in.
+    This works from any subdirectory of the exercise.
+
+    Instead of files, you can name the exercise to submit, e.g.
+    "go/two-fer", or just "two-fer" if there is only one exercise
+    with that slug in your workspace. This submits its default
+    solution files.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.NewConfig()
-
-		usrCfg := viper.New()
-		usrCfg.AddConfigPath(cfg.Dir)
-		usrCfg.SetConfigName("user")
-		usrCfg.SetConfigType("json")
-		// Ignore error. If the file doesn't exist, that is fine.
-		_ = usrCfg.ReadInConfig()
-		cfg.UserViperConfig = usrCfg
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		usrCfg := cfg.UserViperConfig
+		if err := cfg.LoadCredentials(); err != nil {
+			return err
+		}
 
 		v := viper.New()
 		v.AddConfigPath(cfg.Dir)
@@ -46,20 +52,51 @@ var submitCmd = &cobra.Command{
 		// Ignore error. If the file doesn't exist, that is fine.
 		_ = v.ReadInConfig()
 
-		if len(args) == 0 {
-			files, err := getExerciseSolutionFiles(".")
-			if err != nil {
-				return err
-			}
-			args = files
+		files, err := submitFiles(usrCfg, args)
+		if err != nil {
+			return err
 		}
 
-		return runSubmit(cfg, cmd.Flags(), args)
+		return runSubmit(cfg, cmd.Flags(), files)
 	},
 }
 
+// submitFiles determines the files to submit. Without arguments these are the solution files
+// of the exercise in the current directory. A single argument may name an exercise rather
+// than a file, e.g. go/two-fer, to submit its solution files.
+func submitFiles(usrCfg *viper.Viper, args []string) ([]string, error) {
+	dir := ""
+	switch len(args) {
+	case 0:
+		exerciseDir, err := findExerciseDir(".")
+		if err != nil {
+			return nil, err
+		}
+		dir = exerciseDir
+	case 1:
+		exerciseDir, _, err := findExerciseByArg(usrCfg, args[0])
+		if err != nil {
+			return nil, err
+		}
+		dir = exerciseDir
+	}
+	if dir == "" {
+		return args, nil
+	}
+
+	solutionFiles, err := getExerciseSolutionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(solutionFiles))
+	for _, file := range solutionFiles {
+		files = append(files, filepath.Join(dir, file))
+	}
+	return files, nil
+}
+
 func runSubmit(cfg config.Config, flags *pflag.FlagSet, args []string) error {
-	if err := validateUserConfig(cfg.UserViperConfig); err != nil {
+	if err := validateUserConfig(cfg); err != nil {
 		return err
 	}
 
@@ -119,6 +156,11 @@ func runSubmit(cfg config.Config, flags *pflag.FlagSet, args []string) error {
 		return err
 	}
 
+	if err := ctx.recordSubmission(metadata, documents); err != nil {
+		fmt.Fprintf(Err, "\n    WARNING: Unable to record the submission in the exercise metadata: %s\n", err)
+	}
+	autocommit(cfg.UserViperConfig, metadata, "Submit")
+
 	ctx.printResult(metadata)
 	return nil
 }
@@ -305,6 +347,29 @@ func (s *submitCmdContext) submit(metadata *workspace.ExerciseMetadata, docs []w
 	return nil
 }
 
+// recordSubmission stores when the documents were submitted, along with their checksums,
+// to tell later whether the exercise has been modified since, and counts the submissions from this machine.
+func (s *submitCmdContext) recordSubmission(metadata *workspace.ExerciseMetadata, docs []workspace.Document) error {
+	paths := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		paths = append(paths, doc.Path())
+	}
+	updated, err := workspace.UpdateExerciseMetadata(metadata.Dir, func(em *workspace.ExerciseMetadata) error {
+		if err := em.RecordChecksums(paths); err != nil {
+			return err
+		}
+		now := time.Now()
+		em.SubmittedAt = &now
+		em.Iteration++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*metadata = *updated
+	return nil
+}
+
 func (s *submitCmdContext) printResult(metadata *workspace.ExerciseMetadata) {
 	msg := `
 
@@ -376,7 +441,7 @@ func (s submitValidator) filesBelongToSameExercise(submitPaths []string) error {
 		dir, err := ws.ExerciseDir(f)
 		if err != nil {
 			if workspace.IsMissingMetadata(err) {
-				return errors.New(msgMissingMetadata)
+				return fmt.Errorf(msgMissingMetadata, BinaryName)
 			}
 			return err
 		}
@@ -431,17 +496,19 @@ func (s submitValidator) submissionNotEmpty(docs []workspace.Document) error {
 // metadataMatchesExercise checks that the metadata refers to the exercise being submitted.
 func (s submitValidator) metadataMatchesExercise(metadata *workspace.ExerciseMetadata, exercise workspace.Exercise) error {
 	if metadata.ExerciseSlug != exercise.Slug {
-		// TODO: error msg should suggest running future doctor command
 		msg := `
 
     The exercise directory does not match exercise slug in metadata:
 
         expected '%[1]s' but got '%[2]s'
 
-    Please rename the directory '%[1]s' to '%[2]s' and try again.
+    Please rename the directory '%[1]s' to '%[2]s' and try again,
+    or let the doctor repair your workspace:
+
+        %[3]s doctor --fix
 
         `
-		return fmt.Errorf(msg, exercise.Slug, metadata.ExerciseSlug)
+		return fmt.Errorf(msg, exercise.Slug, metadata.ExerciseSlug, BinaryName)
 	}
 	return nil
 }
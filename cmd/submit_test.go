@@ -148,6 +148,38 @@ func TestGetExerciseSolutionFiles(t *testing.T) {
 	}
 }
 
+func TestSubmitFilesForExercise(t *testing.T) {
+	tmpDir, cfg := setupTestWorkspace(t,
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "two-fer", IsRequester: true},
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", IsRequester: true},
+	)
+	for _, slug := range []string{"two-fer", "leap"} {
+		err := os.WriteFile(filepath.Join(tmpDir, "go", slug, ".exercism", "config.json"), []byte(`{"files": {"solution": ["solution.txt"]}}`), os.FileMode(0644))
+		assert.NoError(t, err)
+	}
+	twoFer := []string{filepath.Join(tmpDir, "go", "two-fer", "solution.txt")}
+
+	testCases := []struct {
+		desc string
+		cwd  string
+		args []string
+	}{
+		{desc: "by ID from elsewhere", cwd: t.TempDir(), args: []string{"go/two-fer"}},
+		{desc: "by ID from the workspace root", cwd: tmpDir, args: []string{"go/two-fer"}},
+		{desc: "by slug from the track directory", cwd: filepath.Join(tmpDir, "go"), args: []string{"two-fer"}},
+		{desc: "by directory", cwd: tmpDir, args: []string{filepath.Join(tmpDir, "go", "two-fer")}},
+		{desc: "from within the exercise", cwd: filepath.Join(tmpDir, "go", "two-fer"), args: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Chdir(tc.cwd)
+			files, err := submitFiles(cfg.UserViperConfig, tc.args)
+			assert.NoError(t, err)
+			assert.Equal(t, twoFer, files)
+		})
+	}
+}
+
 func TestSubmitFilesAndDir(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "submit-no-such-file")
 	defer os.RemoveAll(tmpDir)
@@ -276,6 +308,15 @@ func TestSubmitFiles(t *testing.T) {
 		assert.Equal(t, "This is file 2.", submittedFiles["subdir/file-2.txt"])
 		assert.Equal(t, "This is the readme.", submittedFiles["README.md"])
 		assert.Regexp(t, "submitted successfully", Err)
+
+		metadata, err := workspace.NewExerciseMetadata(dir)
+		assert.NoError(t, err)
+		assert.NotNil(t, metadata.SubmittedAt)
+		assert.Len(t, metadata.Checksums, 3)
+		assert.Equal(t, 1, metadata.Iteration)
+		status, _, err := metadata.Status()
+		assert.NoError(t, err)
+		assert.Equal(t, workspace.ExerciseStatusClean, status)
 	}
 }
 
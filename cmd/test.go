@@ -1,84 +1,372 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"os"
-	"os/exec"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/runner"
 	"github.com/exercism/cli/workspace"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var testCmd = &cobra.Command{
-	Use:     "test",
+	Use:     "test [<EXERCISE>] [-- <ARGS>...]",
 	Aliases: []string{"t"},
 	Short:   "Run the exercise's tests.",
 	Long: `Run the exercise's tests.
 
-	Run this command in an exercise's root directory.`,
+	Run this command anywhere within an exercise. The tests are run from
+	the exercise's root directory.
+
+	Alternatively, name the exercise to test, e.g. "go/two-fer", or just
+	"two-fer" if there is only one exercise with that slug in the workspace.
+	Any other arguments, and anything after "--", are passed on to the
+	track's test command.
+
+	Before running the tests, the command checks that the tools the track
+	needs are installed, and explains where to find them if they aren't.
+
+	The test command can be overridden for a whole track or for a single
+	exercise in the "test_commands" section of the user config:
+
+	    "test_commands": {
+	        "python": "python3 -m pytest -x {{test_files}}",
+	        "bash/two-fer": "bats-core {{test_files}}"
+	    }
+
+	An exercise-specific entry takes precedence over a track entry,
+	which takes precedence over the "test_command" in the exercise's
+	.exercism/config.json, which takes precedence over the built-in command.
+	Use --show-command to see which one applies.
+
+	Test commands are Go templates. Besides {{solution_files}}, {{test_files}}
+	and {{slug}}, they can use {{.ExerciseDir}}, {{.Track}}, {{.SnakeCase}},
+	{{.PascalCase}}, {{.CamelCase}}, {{.EditorFiles}}, {{.OS}}, {{.Arch}}
+	and {{env "NAME"}}, as well as conditionals such as
+	{{if eq .OS "windows"}}...{{else}}...{{end}}.
+
+	Use --only to run just the tests that match a pattern, for tracks whose
+	test runner supports it, e.g. "exercism test --only leap_year".
+	A custom test command gets the pattern as {{pattern}}, which is empty
+	without --only, e.g. "pytest{{with pattern}} -k {{.}}{{end}}".
+
+	The result of each test run is stored in .exercism/test-result.json,
+	unless it was interrupted or its output went straight to a terminal.
+	If neither the test command, --sandbox, the environment variables, nor
+	the solution and test files have changed since, the stored result is
+	shown instead of running the tests again, clearly marked as cached.
+	Use --no-cache to always run the tests.
+
+	Use --sandbox to run the tests of code you don't trust, such as someone
+	else's solution. On Linux, it runs the test command without network
+	access (except for localhost), and with the home directory read-only,
+	including anything mounted within it, except for the exercise itself.
+	Tools that write to caches in the home directory need to be pointed
+	elsewhere, e.g. with GOCACHE or XDG_CACHE_HOME.
+	If the system doesn't allow the sandbox, the tests are not run at all.
+
+	Use --all to run the tests of every exercise in the workspace, optionally
+	limited to one --track. Each exercise's output is saved to
+	.exercism/test.log within the exercise, and a summary is printed at the end.
+	The exit code is non-zero if any exercise failed or could not be tested.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runTest(args)
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		run := runTest
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			run = runTestAll
+		}
+		exitCode, err := run(cfg, cmd.Flags(), args)
+		if err != nil {
+			return err
+		}
+		// if the tests failed, exit with the same code
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
 	},
 }
 
-func runTest(args []string) error {
-	track, err := getTrack()
+// exerciseIDRe matches what looks like an exercise ID rather than an argument for the test command,
+// e.g. "two-fer" or "go/two-fer", but not "TestTwoFer" or "test_two_fer".
+var exerciseIDRe = regexp.MustCompile(`\A([a-z0-9]+(-[a-z0-9]+)*/)?[a-z0-9]+(-[a-z0-9]+)*\z`)
+
+// runTest runs the tests of the exercise in the current directory.
+// It returns the exit code of the test command.
+func runTest(cfg config.Config, flags *pflag.FlagSet, args []string) (int, error) {
+	path := "."
+	// The first argument may name an exercise, e.g. go/two-fer.
+	// Anything else is passed on to the test command, as is anything after --.
+	if len(args) > 0 && flags.ArgsLenAtDash() != 0 {
+		exerciseDir, isPath, err := findExerciseByArg(cfg.UserViperConfig, args[0])
+		if err != nil {
+			return 0, err
+		}
+		if exerciseDir == "" && !isPath && exerciseIDRe.MatchString(args[0]) {
+			return 0, exerciseNotFoundError(args[0])
+		}
+		if exerciseDir != "" {
+			path, args = exerciseDir, args[1:]
+		}
+	}
+
+	dir, err := findExerciseDir(path)
+	if err != nil {
+		return 0, err
+	}
+
+	only, err := flags.GetString("only")
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	testConf, ok := workspace.TestConfigurations[track]
+	userCommands := cfg.UserViperConfig.GetStringMapString("test_commands")
+	test, err := newExerciseTest(dir, userCommands, only, args)
+	if err != nil {
+		return 0, err
+	}
 
-	if !ok {
-		return fmt.Errorf("the \"%s\" track does not yet support running tests using the Exercism CLI. Please see HELP.md for testing instructions", track)
+	showCommand, err := flags.GetBool("show-command")
+	if err != nil {
+		return 0, err
+	}
+	if showCommand {
+		fmt.Fprintf(Out, "Command: %s\n", strings.Join(test.command, " "))
+		fmt.Fprintf(Out, "Source:  %s\n", test.config.Source)
+		return 0, nil
+	}
+
+	// A toolchain that has gone missing since the last run is reported, rather than its cached result.
+	if problems := test.toolchain().Check(); len(problems) > 0 {
+		return 0, missingToolsError(test.metadata.Track, problems)
 	}
 
-	command, err := testConf.GetTestCommand()
+	noCache, err := flags.GetBool("no-cache")
 	if err != nil {
-		return err
+		return 0, err
+	}
+	sandbox, err := flags.GetBool("sandbox")
+	if err != nil {
+		return 0, err
+	}
+	hash := test.inputsHash(sandbox)
+	if !noCache {
+		if cached := cachedTestResult(test.dir, hash); cached != nil {
+			printCachedTestResult(test, cached)
+			return cached.ExitCode, nil
+		}
 	}
-	cmdParts := strings.Split(command, " ")
 
-	// pass args/flags to this command down to the test handler
-	if len(args) > 0 {
-		cmdParts = append(cmdParts, args...)
+	fmt.Fprintf(Out, "Running tests via `%s`\n\n", strings.Join(test.command, " "))
+	// pipe output directly out to a terminal, preserving any color
+	inheritOutput := isTerminalOutput()
+	result, err := runner.Run(context.Background(), runner.Options{
+		Command:       test.command,
+		Dir:           test.dir,
+		Stdout:        Out,
+		Stderr:        Err,
+		InheritOutput: inheritOutput,
+		Sandbox:       sandbox,
+	})
+	if errors.Is(err, runner.ErrSandboxUnavailable) {
+		return 0, sandboxUnavailableError(err)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to run the test command: %w", err)
+	}
+	if hash != "" && cacheable(result, inheritOutput) {
+		// Caching is an optimization. Failing to store the result is not a problem.
+		_ = newTestResult(hash, result).Write(test.dir)
+	}
+	return result.ExitCode, nil
+}
+
+// cacheable reports whether the result of a test run can stand in for running the tests again:
+// the run finished by itself, without a timeout or a signal, and its output was recorded.
+func cacheable(result *runner.Result, inheritedOutput bool) bool {
+	return !result.TimedOut && !result.Interrupted && !inheritedOutput
+}
+
+// newTestResult records the outcome of a test run, for reuse while the inputs don't change.
+func newTestResult(hash string, result *runner.Result) *workspace.TestResult {
+	return &workspace.TestResult{
+		Hash:     hash,
+		ExitCode: result.ExitCode,
+		Duration: result.Duration,
+		RanAt:    time.Now(),
+		Output:   string(result.Output),
+	}
+}
+
+// cachedTestResult returns the stored result of the last test run, if it had the same inputs.
+func cachedTestResult(dir, hash string) *workspace.TestResult {
+	if hash == "" {
+		return nil
+	}
+	result, err := workspace.NewTestResult(dir)
+	if err != nil || result.Hash != hash {
+		return nil
+	}
+	return result
+}
+
+func printCachedTestResult(test *exerciseTest, cached *workspace.TestResult) {
+	fmt.Fprintf(Err, "Cached result of `%s` from %s\n", strings.Join(test.command, " "), cached.RanAt.Local().Format(time.RFC1123))
+	fmt.Fprintf(Err, "Nothing has changed since. Use --no-cache to run the tests again.\n\n")
+	if cached.Output == "" {
+		fmt.Fprintf(Err, "The output of that run was not recorded.\n")
+	} else {
+		fmt.Fprint(Out, cached.Output)
 	}
+	fmt.Fprintf(Err, "\n(cached) exit code %d\n", cached.ExitCode)
+}
+
+// unsupportedTrackError signals that there is no way to run a track's tests from the CLI.
+type unsupportedTrackError string
 
-	fmt.Printf("Running tests via `%s`\n\n", strings.Join(cmdParts, " "))
-	exerciseTestCmd := exec.Command(cmdParts[0], cmdParts[1:]...)
+func (track unsupportedTrackError) Error() string {
+	return fmt.Sprintf("the \"%s\" track does not yet support running tests using the Exercism CLI. Please see HELP.md for testing instructions", string(track))
+}
 
-	// pipe output directly out, preserving any color
-	exerciseTestCmd.Stdout = os.Stdout
-	exerciseTestCmd.Stderr = os.Stderr
+// exerciseTest describes how to run an exercise's tests.
+type exerciseTest struct {
+	dir      string
+	metadata *workspace.ExerciseMetadata
+	config   workspace.TestConfiguration
+	command  []string
+}
 
-	err = exerciseTestCmd.Run()
+// newExerciseTest determines the test command for the exercise in dir.
+// If only is given, the command runs just the tests that match it.
+// Any args are passed on to the test command.
+func newExerciseTest(dir string, userCommands map[string]string, only string, args []string) (*exerciseTest, error) {
+	metadata, err := getExerciseMetadata(dir)
 	if err != nil {
-		// unclear what other errors would pop up here, but it pays to be defensive
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			exitCode := exitErr.ExitCode()
-			// if subcommand returned a non-zero exit code, exit with the same
-			os.Exit(exitCode)
-		} else {
-			log.Fatalf("Failed to get error from failed subcommand: %v", err)
+		return nil, err
+	}
+
+	testConf, ok := workspace.LookupTestConfiguration(dir, metadata.Track, metadata.ExerciseSlug, userCommands)
+	if !ok {
+		return nil, unsupportedTrackError(metadata.Track)
+	}
+
+	command, filtered, err := testConf.RenderFilteredTestCommand(dir, only)
+	if err != nil {
+		return nil, err
+	}
+	cmdParts := strings.Fields(command)
+	if len(cmdParts) == 0 {
+		return nil, fmt.Errorf("the test command %q is empty for this exercise", testConf.Command)
+	}
+
+	if only != "" && !filtered {
+		if testConf.Source != workspace.TestCommandSourceBuiltin {
+			return nil, fmt.Errorf("--only is not supported with a custom test command, unless it puts {{pattern}} where the pattern goes. Pass the test runner's own options after --, e.g. `%s test -- <options>`", BinaryName)
+		}
+		filterArgs, err := testConf.GetFilterArgs(only)
+		if err != nil {
+			return nil, fmt.Errorf("the test command of the \"%s\" track does not support --only. Pass the test runner's own options after --, e.g. `%s test -- <options>`", metadata.Track, BinaryName)
 		}
+		cmdParts = append(cmdParts, filterArgs...)
+	}
+
+	// pass args/flags to this command down to the test handler
+	cmdParts = append(cmdParts, args...)
+
+	return &exerciseTest{
+		dir:      dir,
+		metadata: metadata,
+		config:   testConf,
+		command:  cmdParts,
+	}, nil
+}
+
+// inputsHash fingerprints the test run, for caching its result.
+// It is empty if the result can't be cached.
+func (t *exerciseTest) inputsHash(sandbox bool) string {
+	hash, err := workspace.TestInputsHash(t.dir, workspace.TestRun{
+		Command: t.command,
+		Sandbox: sandbox,
+		Env:     os.Environ(),
+	})
+	if err != nil {
+		return ""
 	}
-	return nil
+	return hash
+}
+
+// toolchain is what needs to be installed to run the test command.
+func (t *exerciseTest) toolchain() workspace.Toolchain {
+	return workspace.LookupToolchain(t.metadata.Track, t.config, t.command[0])
 }
 
-func getTrack() (string, error) {
-	metadata, err := workspace.NewExerciseMetadata(".")
+// missingToolsError explains which tools need to be installed before the tests can run.
+func missingToolsError(track string, problems []workspace.ToolProblem) error {
+	tools := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		tools = append(tools, problem.String())
+	}
+	msg := `
+
+    Running the tests for the %s track requires tools that are not installed:
+
+        %s
+
+    See %s for installation instructions.
+
+`
+	return fmt.Errorf(msg, track, strings.Join(tools, "\n        "), workspace.InstallURL(track))
+}
+
+func sandboxUnavailableError(err error) error {
+	msg := `
+
+    The tests were not run, because they can't run in a sandbox on this system:
+
+        %s
+
+    The sandbox needs Linux with unprivileged user namespaces. Some systems
+    restrict them, e.g. with the kernel.unprivileged_userns_clone or
+    kernel.apparmor_restrict_unprivileged_userns settings.
+
+    To run the tests without a sandbox, leave off --sandbox.
+
+`
+	return fmt.Errorf(msg, err)
+}
+
+func getExerciseMetadata(dir string) (*workspace.ExerciseMetadata, error) {
+	metadata, err := workspace.NewExerciseMetadata(dir)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if metadata.Track == "" {
-		return "", fmt.Errorf("no track found in exercise metadata")
+		return nil, fmt.Errorf("no track found in exercise metadata")
 	}
 
-	return metadata.Track, nil
+	return metadata, nil
+}
+
+func setupTestFlags(flags *pflag.FlagSet) {
+	flags.BoolP("show-command", "", false, "print the test command and where it was configured, without running it")
+	flags.StringP("only", "", "", "only run the tests that match the pattern")
+	flags.BoolP("no-cache", "", false, "run the tests even if nothing has changed since the last run")
+	flags.BoolP("sandbox", "", false, "run the tests without network access and with a read-only home directory (Linux only)")
 }
 
 func init() {
 	RootCmd.AddCommand(testCmd)
+	setupTestFlags(testCmd.Flags())
+	setupTestAllFlags(testCmd.Flags())
 }
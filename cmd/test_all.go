@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/runner"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+)
+
+// testOutcome is the result of running one exercise's tests.
+type testOutcome int
+
+const (
+	testOutcomePass testOutcome = iota
+	testOutcomeFail
+	testOutcomeError
+	testOutcomeUnsupported
+)
+
+func (o testOutcome) String() string {
+	switch o {
+	case testOutcomePass:
+		return "pass"
+	case testOutcomeFail:
+		return "fail"
+	case testOutcomeError:
+		return "error"
+	default:
+		return "unsupported"
+	}
+}
+
+// exerciseTestRun records a test run of a single exercise within the workspace.
+type exerciseTestRun struct {
+	exercise workspace.Exercise
+	outcome  testOutcome
+	duration time.Duration
+	logPath  string
+	cached   bool
+	err      error
+}
+
+// runTestAll runs the tests of every exercise in the workspace.
+// It returns the exit code for the whole run: zero if nothing failed or errored.
+func runTestAll(cfg config.Config, flags *pflag.FlagSet, args []string) (int, error) {
+	usrCfg := cfg.UserViperConfig
+	if usrCfg.GetString("workspace") == "" {
+		return 0, fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	track, err := flags.GetString("track")
+	if err != nil {
+		return 0, err
+	}
+	jobs, err := flags.GetInt("jobs")
+	if err != nil {
+		return 0, err
+	}
+	if jobs < 1 {
+		return 0, errors.New("--jobs must be at least 1")
+	}
+	timeout, err := flags.GetDuration("exercise-timeout")
+	if err != nil {
+		return 0, err
+	}
+	only, err := flags.GetString("only")
+	if err != nil {
+		return 0, err
+	}
+	noCache, err := flags.GetBool("no-cache")
+	if err != nil {
+		return 0, err
+	}
+	sandbox, err := flags.GetBool("sandbox")
+	if err != nil {
+		return 0, err
+	}
+
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return 0, err
+	}
+	exercises, err := ws.Exercises()
+	if err != nil {
+		return 0, err
+	}
+	if track != "" {
+		filtered := exercises[:0]
+		for _, exercise := range exercises {
+			if exercise.Track == track {
+				filtered = append(filtered, exercise)
+			}
+		}
+		exercises = filtered
+	}
+	if len(exercises) == 0 {
+		return 0, errors.New("no exercises found in the workspace")
+	}
+
+	userCommands := usrCfg.GetStringMapString("test_commands")
+	runs := make([]*exerciseTestRun, len(exercises))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, exercise := range exercises {
+		runs[i] = &exerciseTestRun{exercise: exercise}
+		wg.Add(1)
+		go func(run *exerciseTestRun) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			run.test(userCommands, only, noCache, sandbox, timeout, args)
+
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Fprintf(Err, "%-11s %s\n", run.outcome, run.exercise.Path())
+		}(runs[i])
+	}
+	wg.Wait()
+
+	return printTestAllSummary(runs), nil
+}
+
+// test runs the exercise's tests, writing the output to a log file in the exercise.
+// The result is reused from the last run if nothing has changed, unless noCache is set.
+func (run *exerciseTestRun) test(userCommands map[string]string, only string, noCache, sandbox bool, timeout time.Duration, args []string) {
+	test, err := newExerciseTest(run.exercise.Filepath(), userCommands, only, args)
+	var unsupported unsupportedTrackError
+	if errors.As(err, &unsupported) {
+		run.outcome = testOutcomeUnsupported
+		return
+	}
+	if err != nil {
+		run.outcome, run.err = testOutcomeError, err
+		return
+	}
+
+	// A toolchain that has gone missing since the last run is reported, rather than its cached result.
+	if problems := test.toolchain().Check(); len(problems) > 0 {
+		missing := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			missing = append(missing, problem.String())
+		}
+		run.outcome, run.err = testOutcomeError, fmt.Errorf("missing %s", strings.Join(missing, ", "))
+		return
+	}
+
+	run.logPath = filepath.Join(filepath.Dir(run.exercise.MetadataFilepath()), workspace.TestLogFilename)
+	hash := test.inputsHash(sandbox)
+	if !noCache {
+		if cached := cachedTestResult(test.dir, hash); cached != nil {
+			run.cached = true
+			run.duration = cached.Duration
+			run.outcome = testOutcomePass
+			if cached.ExitCode != 0 {
+				run.outcome = testOutcomeFail
+			}
+			// The log of the run that was cached may have been overwritten since, e.g. by a run with --only.
+			if err := writeCachedTestLog(run.logPath, test, cached); err != nil {
+				run.logPath = ""
+			}
+			return
+		}
+	}
+
+	logFile, err := os.Create(run.logPath)
+	if err != nil {
+		run.outcome, run.err = testOutcomeError, err
+		return
+	}
+	defer logFile.Close()
+	fmt.Fprintf(logFile, "Running tests via `%s`\n\n", strings.Join(test.command, " "))
+
+	result, err := runner.Run(context.Background(), runner.Options{
+		Command: test.command,
+		Dir:     test.dir,
+		Stdout:  logFile,
+		Stderr:  logFile,
+		Timeout: timeout,
+		Sandbox: sandbox,
+	})
+	if err != nil {
+		run.outcome, run.err = testOutcomeError, err
+		fmt.Fprintf(logFile, "\n%s\n", run.err)
+		return
+	}
+
+	run.duration = result.Duration
+	if hash != "" && cacheable(result, false) {
+		// Caching is an optimization. Failing to store the result is not a problem.
+		_ = newTestResult(hash, result).Write(test.dir)
+	}
+	switch {
+	case result.TimedOut:
+		run.outcome, run.err = testOutcomeError, fmt.Errorf("timed out after %s", timeout)
+	case result.Passed():
+		run.outcome = testOutcomePass
+	default:
+		run.outcome = testOutcomeFail
+	}
+	if run.err != nil {
+		fmt.Fprintf(logFile, "\n%s\n", run.err)
+	}
+}
+
+// writeCachedTestLog writes the output of a cached test run to the log file,
+// so that the log always belongs to the result that is reported.
+func writeCachedTestLog(path string, test *exerciseTest, cached *workspace.TestResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cached result of `%s` from %s\n\n", strings.Join(test.command, " "), cached.RanAt.Local().Format(time.RFC1123))
+	b.WriteString(cached.Output)
+	return os.WriteFile(path, []byte(b.String()), os.FileMode(0644))
+}
+
+// printTestAllSummary prints a table of the test runs, and returns the aggregate exit code.
+func printTestAllSummary(runs []*exerciseTestRun) int {
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].exercise.Path() < runs[j].exercise.Path()
+	})
+
+	counts := map[testOutcome]int{}
+	w := tabwriter.NewWriter(Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "EXERCISE\tRESULT\tDURATION\tLOG")
+	for _, run := range runs {
+		counts[run.outcome]++
+		result := run.outcome.String()
+		if run.cached {
+			result = fmt.Sprintf("%s (cached)", result)
+		}
+		if run.err != nil {
+			result = fmt.Sprintf("%s: %s", result, run.err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", run.exercise.Path(), result, run.duration.Round(time.Millisecond), run.logPath)
+	}
+	w.Flush()
+
+	fmt.Fprintf(Out, "\n%d passed, %d failed, %d errored, %d unsupported\n",
+		counts[testOutcomePass], counts[testOutcomeFail], counts[testOutcomeError], counts[testOutcomeUnsupported])
+
+	if counts[testOutcomeFail] > 0 || counts[testOutcomeError] > 0 {
+		return 1
+	}
+	return 0
+}
+
+func setupTestAllFlags(flags *pflag.FlagSet) {
+	flags.BoolP("all", "", false, "run the tests of every exercise in the workspace")
+	flags.StringP("track", "", "", "with --all, only test exercises in this track")
+	flags.IntP("jobs", "j", runtime.NumCPU(), "with --all, the number of exercises to test in parallel")
+	flags.DurationP("exercise-timeout", "", 10*time.Minute, "with --all, the maximum time to spend testing one exercise (0 for no limit)")
+}
@@ -0,0 +1,199 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestAll(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	tmpDir, err := os.MkdirTemp("", "test-all")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	for _, em := range []workspace.ExerciseMetadata{
+		{Track: "bogus-track", ExerciseSlug: "passing", IsRequester: true},
+		{Track: "bogus-track", ExerciseSlug: "failing", IsRequester: true},
+		{Track: "bogus-track", ExerciseSlug: "hanging", IsRequester: true},
+		{Track: "unsupported-track", ExerciseSlug: "whatever", IsRequester: true},
+	} {
+		err := em.Write(filepath.Join(tmpDir, em.Track, em.ExerciseSlug))
+		assert.NoError(t, err)
+	}
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	v.Set("test_commands", map[string]string{
+		"bogus-track/passing": "echo all good",
+		"bogus-track/failing": "false",
+		"bogus-track/hanging": "sleep 10",
+	})
+	cfg := config.Config{UserViperConfig: v}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupTestFlags(flags)
+	setupTestAllFlags(flags)
+	err = flags.Parse([]string{"--all", "--exercise-timeout", "500ms"})
+	assert.NoError(t, err)
+
+	exitCode, err := runTestAll(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+
+	out := Out.(*bytes.Buffer).String()
+	assert.Regexp(t, `bogus-track/failing\s+fail`, out)
+	assert.Regexp(t, `bogus-track/hanging\s+error: timed out after 500ms`, out)
+	assert.Regexp(t, `bogus-track/passing\s+pass`, out)
+	assert.Regexp(t, `unsupported-track/whatever\s+unsupported`, out)
+	assert.Contains(t, out, "1 passed, 1 failed, 1 errored, 1 unsupported")
+
+	log, err := os.ReadFile(filepath.Join(tmpDir, "bogus-track", "passing", ".exercism", workspace.TestLogFilename))
+	assert.NoError(t, err)
+	assert.Contains(t, string(log), "all good")
+}
+
+func TestTestAllFiltersByTrack(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	tmpDir, err := os.MkdirTemp("", "test-all-track")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	for _, em := range []workspace.ExerciseMetadata{
+		{Track: "bogus-track", ExerciseSlug: "passing", IsRequester: true},
+		{Track: "other-track", ExerciseSlug: "failing", IsRequester: true},
+	} {
+		err := em.Write(filepath.Join(tmpDir, em.Track, em.ExerciseSlug))
+		assert.NoError(t, err)
+	}
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	v.Set("test_commands", map[string]string{"bogus-track": "true", "other-track": "false"})
+	cfg := config.Config{UserViperConfig: v}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupTestFlags(flags)
+	setupTestAllFlags(flags)
+	err = flags.Parse([]string{"--all", "--track", "bogus-track", "--jobs", "1", "--exercise-timeout", time.Minute.String()})
+	assert.NoError(t, err)
+
+	exitCode, err := runTestAll(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.NotContains(t, Out.(*bytes.Buffer).String(), "other-track")
+}
+
+func TestTestAllReusesCachedResults(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	tmpDir, err := os.MkdirTemp("", "test-all-cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	em := workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "passing", IsRequester: true}
+	dir := filepath.Join(tmpDir, em.Track, em.ExerciseSlug)
+	err = em.Write(dir)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["passing.sh"], "test": ["passing_test.sh"]}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "passing.sh"), []byte("true"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	v.Set("test_commands", map[string]string{"bogus-track": "echo all good"})
+	cfg := config.Config{UserViperConfig: v}
+
+	run := func(args ...string) string {
+		Out = &bytes.Buffer{}
+		flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+		setupTestFlags(flags)
+		setupTestAllFlags(flags)
+		err := flags.Parse(append([]string{"--all"}, args...))
+		assert.NoError(t, err)
+
+		exitCode, err := runTestAll(cfg, flags, []string{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, exitCode)
+		return Out.(*bytes.Buffer).String()
+	}
+
+	assert.NotContains(t, run(), "(cached)")
+	logPath := filepath.Join(dir, ".exercism", workspace.TestLogFilename)
+	err = os.Remove(logPath)
+	assert.NoError(t, err)
+	assert.Regexp(t, `bogus-track/passing\s+pass \(cached\)`, run())
+	// The log shows the cached output, rather than whatever the last run left behind.
+	log, err := os.ReadFile(logPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(log), "Cached result of `echo all good`")
+	assert.Contains(t, string(log), "all good\n")
+	assert.NotContains(t, run("--no-cache"), "(cached)")
+
+	err = os.WriteFile(filepath.Join(dir, "passing.sh"), []byte("true # changed"), os.FileMode(0644))
+	assert.NoError(t, err)
+	assert.NotContains(t, run(), "(cached)")
+}
+
+func TestTestAllReportsMissingToolchainOverCachedResult(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	binDir := t.TempDir()
+	runnerPath := filepath.Join(binDir, "fake-test-runner")
+	err := os.WriteFile(runnerPath, []byte("#!/bin/sh\necho all good\n"), os.FileMode(0755))
+	assert.NoError(t, err)
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmpDir, cfg := setupTestWorkspace(t, workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "passing", IsRequester: true})
+	dir := filepath.Join(tmpDir, "bogus-track", "passing")
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["solution.txt"], "test": []}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	cfg.UserViperConfig.Set("test_commands", map[string]string{"bogus-track": "fake-test-runner"})
+
+	run := func() (int, string) {
+		Out = &bytes.Buffer{}
+		flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+		setupTestFlags(flags)
+		setupTestAllFlags(flags)
+		err := flags.Parse([]string{"--all"})
+		assert.NoError(t, err)
+
+		exitCode, err := runTestAll(cfg, flags, []string{})
+		assert.NoError(t, err)
+		return exitCode, Out.(*bytes.Buffer).String()
+	}
+
+	exitCode, _ := run()
+	assert.Equal(t, 0, exitCode)
+
+	// The test runner was uninstalled since.
+	err = os.Remove(runnerPath)
+	assert.NoError(t, err)
+	exitCode, out := run()
+	assert.NotEqual(t, 0, exitCode)
+	assert.NotContains(t, out, "(cached)")
+	assert.Contains(t, out, "fake-test-runner")
+}
@@ -0,0 +1,50 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestDoesNotCacheInterruptedRun(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	oldIsTerminalOutput := isTerminalOutput
+	isTerminalOutput = func() bool { return false }
+	defer func() { isTerminalOutput = oldIsTerminalOutput }()
+
+	tmpDir := t.TempDir()
+	em := workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "interrupted", IsRequester: true}
+	dir := filepath.Join(tmpDir, em.Track, em.ExerciseSlug)
+	err := em.Write(dir)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["interrupted.sh"]}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "interrupted.sh"), []byte("kill -TERM $$"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	v.Set("test_commands", map[string]string{"bogus-track": "sh interrupted.sh"})
+	cfg := config.Config{UserViperConfig: v}
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupTestFlags(flags)
+	t.Chdir(dir)
+	exitCode, err := runTest(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, 128+15, exitCode)
+
+	_, err = os.Stat(filepath.Join(dir, ".exercism", "test-result.json"))
+	assert.True(t, os.IsNotExist(err))
+}
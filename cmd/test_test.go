@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestOnly(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-only")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	for _, em := range []workspace.ExerciseMetadata{
+		{Track: "go", ExerciseSlug: "leap", IsRequester: true},
+		{Track: "c", ExerciseSlug: "leap", IsRequester: true},
+	} {
+		err := em.Write(filepath.Join(tmpDir, em.Track, em.ExerciseSlug))
+		assert.NoError(t, err)
+	}
+
+	test, err := newExerciseTest(filepath.Join(tmpDir, "go", "leap"), nil, "TestLeapYear", []string{"-v"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "test", "-run", "TestLeapYear", "-v"}, test.command)
+
+	_, err = newExerciseTest(filepath.Join(tmpDir, "c", "leap"), nil, "test_leap_year", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "does not support --only")
+	}
+
+	// a custom command doesn't get the track's filter
+	_, err = newExerciseTest(filepath.Join(tmpDir, "go", "leap"), map[string]string{"go": "gotestsum"}, "TestLeapYear", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "--only is not supported with a custom test command")
+	}
+
+	// unless it says where the pattern goes
+	userCommands := map[string]string{"go": "gotestsum --{{with pattern}} -run {{.}}{{end}}"}
+	test, err = newExerciseTest(filepath.Join(tmpDir, "go", "leap"), userCommands, "TestLeapYear", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gotestsum", "--", "-run", "TestLeapYear"}, test.command)
+}
+
+func TestTestUnknownExercise(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	em := workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", IsRequester: true}
+	err := em.Write(filepath.Join(tmpDir, em.Track, em.ExerciseSlug))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("workspace", tmpDir)
+	cfg := config.Config{UserViperConfig: v}
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupTestFlags(flags)
+
+	_, err = runTest(cfg, flags, []string{"go/tow-fer"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "There is no exercise 'go/tow-fer' in your workspace.")
+	}
+}
+
+func TestTestExerciseFromWithinWorkspace(t *testing.T) {
+	co := newCapturedOutput()
+	co.newOut = &bytes.Buffer{}
+	co.override()
+	defer co.reset()
+
+	tmpDir, cfg := setupTestWorkspace(t,
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "two-fer", IsRequester: true},
+		workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", IsRequester: true},
+	)
+	cfg.UserViperConfig.Set("test_commands", map[string]string{"go": "go-test {{.ExerciseDir}}"})
+	twoFer := filepath.Join(tmpDir, "go", "two-fer")
+
+	testCases := []struct {
+		desc string
+		cwd  string
+		args []string
+	}{
+		{desc: "by ID from the workspace root", cwd: tmpDir, args: []string{"go/two-fer", "-v"}},
+		{desc: "by slug from the track directory", cwd: filepath.Join(tmpDir, "go"), args: []string{"two-fer", "-v"}},
+		{desc: "by ID from another exercise", cwd: filepath.Join(tmpDir, "go", "leap"), args: []string{"go/two-fer", "-v"}},
+		{desc: "from within the exercise", cwd: twoFer, args: []string{"-v"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			Out = &bytes.Buffer{}
+			t.Chdir(tc.cwd)
+			flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+			setupTestFlags(flags)
+			err := flags.Set("show-command", "true")
+			assert.NoError(t, err)
+
+			_, err = runTest(cfg, flags, tc.args)
+			assert.NoError(t, err)
+			assert.Contains(t, Out.(*bytes.Buffer).String(), "Command: go-test "+twoFer+" -v\n")
+		})
+	}
+}
+
+func TestTestCommandWithEmptyValues(t *testing.T) {
+	tmpDir, _ := setupTestWorkspace(t, workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", IsRequester: true})
+	dir := filepath.Join(tmpDir, "go", "leap")
+	err := os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["leap.go"]}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	// Empty values and extra spaces don't turn into empty arguments.
+	userCommands := map[string]string{"go": "printf [%s]  {{.EditorFiles}} {{pattern}} x"}
+	test, err := newExerciseTest(dir, userCommands, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"printf", "[%s]", "x"}, test.command)
+}
+
+func TestTestCommandRendersEmpty(t *testing.T) {
+	tmpDir, _ := setupTestWorkspace(t, workspace.ExerciseMetadata{Track: "go", ExerciseSlug: "leap", IsRequester: true})
+
+	userCommands := map[string]string{"go": `{{if eq .OS "plan9"}}go test{{end}}`}
+	_, err := newExerciseTest(filepath.Join(tmpDir, "go", "leap"), userCommands, "", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is empty for this exercise")
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"github.com/exercism/cli/cli"
 	"github.com/exercism/cli/config"
 	"github.com/exercism/cli/debug"
+	"github.com/exercism/cli/transport"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // fullAPIKey flag for troubleshoot command.
@@ -32,16 +32,13 @@ command into a topic on the Exercism forum so we can help figure out what's goin
 		cli.TimeoutInSeconds = cli.TimeoutInSeconds * 2
 		c := cli.New(Version)
 
-		cfg := config.NewConfig()
-
-		v := viper.New()
-		v.AddConfigPath(cfg.Dir)
-		v.SetConfigName("user")
-		v.SetConfigType("json")
-		// Ignore error. If the file doesn't exist, that is fine.
-		_ = v.ReadInConfig()
-
-		cfg.UserViperConfig = v
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+		if err := cfg.LoadCredentials(); err != nil {
+			return err
+		}
 
 		status := newStatus(c, cfg)
 		status.Censor = !fullAPIKey
@@ -61,6 +58,7 @@ type Status struct {
 	Version         versionStatus
 	System          systemStatus
 	Configuration   configurationStatus
+	Network         networkStatus
 	APIReachability apiReachabilityStatus
 	cfg             config.Config
 	cli             *cli.CLI
@@ -88,6 +86,14 @@ type configurationStatus struct {
 	TokenURL  string
 }
 
+type networkStatus struct {
+	Proxy         string
+	CAFile        string
+	ClientCert    string
+	MinTLSVersion string
+	Error         error
+}
+
 type apiReachabilityStatus struct {
 	Services []*apiPing
 }
@@ -113,6 +119,7 @@ func (status *Status) check() (string, error) {
 	status.Version = newVersionStatus(status.cli)
 	status.System = newSystemStatus()
 	status.Configuration = newConfigurationStatus(status)
+	status.Network = newNetworkStatus(status.cfg)
 	status.APIReachability = newAPIReachabilityStatus(status.cfg)
 
 	return status.compile()
@@ -199,6 +206,26 @@ func newConfigurationStatus(status *Status) configurationStatus {
 	return cs
 }
 
+func newNetworkStatus(cfg config.Config) networkStatus {
+	settings := transport.FromConfig(cfg.UserViperConfig)
+	ns := networkStatus{
+		CAFile:        settings.CAFile,
+		MinTLSVersion: settings.MinTLSVersion,
+	}
+	if settings.ClientCert != "" {
+		ns.ClientCert = fmt.Sprintf("%s (key: %s)", settings.ClientCert, settings.ClientKey)
+	}
+	if _, err := settings.New(); err != nil {
+		ns.Error = fmt.Errorf("Error: %s", err)
+	}
+	proxy, err := settings.ProxyFor(cfg.UserViperConfig.GetString("apibaseurl"))
+	if err != nil {
+		proxy = err.Error()
+	}
+	ns.Proxy = proxy
+	return ns
+}
+
 func (ping *apiPing) Call(wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -246,6 +273,15 @@ Config:    {{ .Configuration.Dir }}
 API key:   {{ with .Configuration.Token }}{{ . }}{{ else }}<not configured>
 Find your API key at {{ .Configuration.TokenURL }}{{ end }}
 
+Network
+----------------
+Proxy:           {{ .Network.Proxy }}
+CA file:         {{ with .Network.CAFile }}{{ . }}{{ else }}<system>{{ end }}
+Client cert:     {{ with .Network.ClientCert }}{{ . }}{{ else }}<none>{{ end }}
+Min TLS version: {{ with .Network.MinTLSVersion }}{{ . }}{{ else }}<default>{{ end }}
+{{ with .Network.Error }}
+{{ . }}
+{{ end }}
 API Reachability
 ----------------
 {{ range .APIReachability.Services }}
@@ -22,6 +22,7 @@ The next time you upgrade, the hidden file will be overwritten.
 You can always delete this file.
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		setupNetwork(cmd.Flags())
 		c := cli.New(Version)
 		err := updateCLI(c)
 		if err != nil {
@@ -29,6 +29,7 @@ To check for the latest available version, call the command with the
 		fmt.Println(currentVersion())
 
 		if checkLatest {
+			setupNetwork(cmd.Flags())
 			c := cli.New(Version)
 			l, err := checkForUpdate(c)
 			if err != nil {
@@ -3,9 +3,7 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/exercism/cli/config"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // workspaceCmd outputs the path to the person's workspace directory.
@@ -25,18 +23,16 @@ For example you can run:
 On Windows, this will work only with Powershell, however you would
 need to be on the same drive as your workspace directory. Otherwise
 nothing will happen.
+
+To move the workspace along with its exercises, use "workspace move".
 	`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.NewConfig()
-
-		v := viper.New()
-		v.AddConfigPath(cfg.Dir)
-		v.SetConfigName("user")
-		v.SetConfigType("json")
-		// Ignore error. If the file doesn't exist, that is fine.
-		_ = v.ReadInConfig()
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
 
-		fmt.Fprintf(Out, "%s\n", v.GetString("workspace"))
+		fmt.Fprintf(Out, "%s\n", cfg.UserViperConfig.GetString("workspace"))
 		return nil
 	},
 }
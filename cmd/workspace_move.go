@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/exercism/cli/atomicfile"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+)
+
+// workspaceMoveJournalFilename is the file in the config directory that records a move in progress.
+const workspaceMoveJournalFilename = "workspace-move.json"
+
+// workspaceMoveCmd moves the workspace, along with all of its exercises.
+var workspaceMoveCmd = &cobra.Command{
+	Use:   "move <NEWPATH>",
+	Short: "Move your workspace to a new location.",
+	Long: `Move your workspace to a new location.
+
+This moves all of the exercises in the workspace, and then updates
+the configuration to point to the new location.
+
+The new location must not exist yet, or be an empty directory.
+On the same filesystem the workspace is simply renamed. Otherwise it is
+copied, the copy is checked against the original, and only then is the
+original removed.
+
+If the move is interrupted, run the same command again to finish it.
+
+The workspace must be configured in the config file, or in the profile
+that is used. One that is set with EXERCISM_WORKSPACE or --workspace
+can't be updated by this command.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runWorkspaceMove(cfg, args[0])
+	},
+}
+
+// workspaceMoveJournal records a workspace move, so that it can be resumed after an interruption.
+type workspaceMoveJournal struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Moved is set once the new location holds the complete workspace, and the config points to it.
+	// All that's left then is to remove the old location.
+	Moved bool `json:"moved"`
+	path  string
+}
+
+func readWorkspaceMoveJournal(dir string) (*workspaceMoveJournal, error) {
+	path := filepath.Join(dir, workspaceMoveJournalFilename)
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	journal := &workspaceMoveJournal{path: path}
+	if err := json.Unmarshal(b, journal); err != nil {
+		return nil, fmt.Errorf("invalid workspace move journal %s: %w", path, err)
+	}
+	return journal, nil
+}
+
+func (j *workspaceMoveJournal) write() error {
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), os.FileMode(0700)); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(j.path, b, os.FileMode(0600))
+}
+
+func runWorkspaceMove(cfg config.Config, newPath string) error {
+	usrCfg := cfg.UserViperConfig
+	to, err := filepath.Abs(newPath)
+	if err != nil {
+		return err
+	}
+
+	journal, err := readWorkspaceMoveJournal(cfg.Dir)
+	if err != nil {
+		return err
+	}
+	if journal != nil && journal.To != to {
+		msg := `
+
+    A move of the workspace from %s to %s was interrupted.
+    Finish it before moving the workspace again:
+
+        %s workspace move %s
+
+`
+		return fmt.Errorf(msg, journal.From, journal.To, BinaryName, journal.To)
+	}
+	if journal == nil {
+		if usrCfg.GetString("workspace") == "" {
+			return fmt.Errorf(msgRerunConfigure, BinaryName)
+		}
+		if err := checkWorkspaceSource(cfg); err != nil {
+			return err
+		}
+		from, err := filepath.Abs(usrCfg.GetString("workspace"))
+		if err != nil {
+			return err
+		}
+		if err := checkWorkspaceMove(from, to); err != nil {
+			return err
+		}
+		journal = &workspaceMoveJournal{
+			From: from,
+			To:   to,
+			path: filepath.Join(cfg.Dir, workspaceMoveJournalFilename),
+		}
+		if err := journal.write(); err != nil {
+			return err
+		}
+	}
+
+	if !journal.Moved {
+		if err := moveWorkspaceTree(journal.From, journal.To); err != nil {
+			return fmt.Errorf("failed to move the workspace, run the command again to resume: %w", err)
+		}
+		usrCfg.Set("workspace", journal.To)
+		if err := cfg.Save("user"); err != nil {
+			return err
+		}
+		journal.Moved = true
+		if err := journal.write(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.RemoveAll(journal.From); err != nil {
+		return fmt.Errorf("the workspace was moved, but the old location could not be removed: %w", err)
+	}
+	if err := os.Remove(journal.path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(Err, "\nMoved the workspace from %s to\n", journal.From)
+	fmt.Fprintf(Out, "%s\n", journal.To)
+	return nil
+}
+
+// checkWorkspaceSource checks that the move can record the new location where the workspace is read from.
+// An environment variable or a flag can't be changed, and the user config file's workspace is shared by the profiles.
+func checkWorkspaceSource(cfg config.Config) error {
+	workspace := cfg.UserViperConfig.GetString("workspace")
+	source := cfg.Source("workspace")
+	if source == config.EnvVars["workspace"] || source == "--workspace" {
+		msg := `
+
+    The workspace %s is set with %s, which this command can't change.
+    Run it without %s to move the workspace of the config file,
+    or move the directory yourself and update %s.
+
+`
+		return fmt.Errorf(msg, workspace, source, source, source)
+	}
+	if cfg.Profile != "" && source == cfg.File {
+		msg := `
+
+    The workspace %s comes from %s, which all profiles share,
+    while the move would only update the profile %q.
+    Run the command without a profile to move it for all of them.
+
+`
+		return fmt.Errorf(msg, workspace, source, cfg.Profile)
+	}
+	return nil
+}
+
+// checkWorkspaceMove checks that a move can start.
+func checkWorkspaceMove(from, to string) error {
+	if from == to {
+		return fmt.Errorf("the workspace is already at %s", to)
+	}
+	if rel, err := filepath.Rel(from, to); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("can't move the workspace into itself, %s is within %s", to, from)
+	}
+	info, err := os.Stat(from)
+	if err != nil {
+		return fmt.Errorf("can't find the workspace: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("the workspace %s is not a directory", from)
+	}
+
+	entries, err := os.ReadDir(to)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty, choose a new or empty directory", to)
+	}
+	return nil
+}
+
+// moveWorkspaceTree moves the directory tree at from to to, renaming it if possible,
+// and copying it otherwise, e.g. to another filesystem.
+// The original is left in place after a copy, to be removed once the move is recorded.
+// Running it again after an interruption picks up where it left off.
+func moveWorkspaceTree(from, to string) error {
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		// It was renamed before the interruption.
+		if _, err := os.Stat(to); err != nil {
+			return errors.New("neither the old nor the new location of the workspace exists")
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(to), os.FileMode(0755)); err != nil {
+		return err
+	}
+	// Renaming replaces an empty directory. A partial copy from an earlier attempt stays.
+	_ = os.Remove(to)
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+
+	if err := workspace.CopyTree(from, to); err != nil {
+		return err
+	}
+	return workspace.VerifyTree(from, to)
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceMoveCmd)
+}
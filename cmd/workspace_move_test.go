@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupWorkspaceMoveTest(t *testing.T) (string, config.Config) {
+	from, cfg := setupTestWorkspace(t, workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+	cfg.Dir = t.TempDir()
+	cfg.Persister = config.FilePersister{Dir: cfg.Dir}
+	return from, cfg
+}
+
+func TestWorkspaceMove(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	from, cfg := setupWorkspaceMoveTest(t)
+	to := filepath.Join(t.TempDir(), "new", "workspace")
+
+	err := runWorkspaceMove(cfg, to)
+	assert.NoError(t, err)
+
+	assert.Equal(t, to+"\n", Out.(*bytes.Buffer).String())
+	assert.FileExists(t, filepath.Join(to, "bogus-track", "bogus-exercise", "solution.txt"))
+	assert.NoDirExists(t, from)
+	assert.NoFileExists(t, filepath.Join(cfg.Dir, workspaceMoveJournalFilename))
+
+	b, err := os.ReadFile(filepath.Join(cfg.Dir, "user.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), to)
+}
+
+func TestWorkspaceMoveToNonEmptyDir(t *testing.T) {
+	from, cfg := setupWorkspaceMoveTest(t)
+	to := t.TempDir()
+	err := os.WriteFile(filepath.Join(to, "file.txt"), []byte("taken"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	err = runWorkspaceMove(cfg, to)
+	if assert.Error(t, err) {
+		assert.Regexp(t, "already exists and is not empty", err.Error())
+	}
+	assert.DirExists(t, from)
+}
+
+func TestWorkspaceMoveIntoItself(t *testing.T) {
+	from, cfg := setupWorkspaceMoveTest(t)
+
+	err := runWorkspaceMove(cfg, filepath.Join(from, "nested"))
+	if assert.Error(t, err) {
+		assert.Regexp(t, "into itself", err.Error())
+	}
+}
+
+func TestWorkspaceMoveIntoDirStartingWithDots(t *testing.T) {
+	from, cfg := setupWorkspaceMoveTest(t)
+
+	err := runWorkspaceMove(cfg, filepath.Join(from, "..archive"))
+	if assert.Error(t, err) {
+		assert.Regexp(t, "into itself", err.Error())
+	}
+}
+
+func TestWorkspaceMoveOverriddenWorkspace(t *testing.T) {
+	from, _ := setupWorkspaceMoveTest(t)
+
+	loadSettingsTestConfig(t, `{"workspace": "/somewhere/else"}`)
+	t.Setenv("EXERCISM_WORKSPACE", from)
+	cfg, err := config.Load(nil, false)
+	assert.NoError(t, err)
+
+	err = runWorkspaceMove(cfg, filepath.Join(t.TempDir(), "new"))
+	if assert.Error(t, err) {
+		assert.Regexp(t, "is set with EXERCISM_WORKSPACE, which this command can't change", err.Error())
+	}
+	assert.DirExists(t, from)
+	assert.NoFileExists(t, filepath.Join(cfg.Dir, workspaceMoveJournalFilename))
+}
+
+func TestWorkspaceMoveResumesInterruptedCopy(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+
+	from, cfg := setupWorkspaceMoveTest(t)
+	tmpDir := t.TempDir()
+	to := filepath.Join(tmpDir, "new")
+
+	// The copy was interrupted halfway through a file.
+	err := os.MkdirAll(filepath.Join(to, "bogus-track", "bogus-exercise"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(to, "bogus-track", "bogus-exercise", "solution.txt"), []byte("sol"), os.FileMode(0644))
+	assert.NoError(t, err)
+	journal := &workspaceMoveJournal{From: from, To: to, path: filepath.Join(cfg.Dir, workspaceMoveJournalFilename)}
+	err = journal.write()
+	assert.NoError(t, err)
+
+	err = runWorkspaceMove(cfg, filepath.Join(tmpDir, "elsewhere"))
+	if assert.Error(t, err) {
+		assert.Regexp(t, "was interrupted", err.Error())
+	}
+
+	err = runWorkspaceMove(cfg, to)
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(to, "bogus-track", "bogus-exercise", "solution.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "solution", string(b))
+	assert.NoDirExists(t, from)
+	assert.Equal(t, to, cfg.UserViperConfig.GetString("workspace"))
+}
+
+func TestWorkspaceMoveJournal(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	journal, err := readWorkspaceMoveJournal(tmpDir)
+	assert.NoError(t, err)
+	assert.Nil(t, journal)
+
+	journal = &workspaceMoveJournal{From: "/a", To: "/b", Moved: true, path: filepath.Join(tmpDir, workspaceMoveJournalFilename)}
+	err = journal.write()
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(journal.path)
+	assert.NoError(t, err)
+	var stored map[string]interface{}
+	err = json.Unmarshal(b, &stored)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"from": "/a", "to": "/b", "moved": true}, stored)
+
+	journal, err = readWorkspaceMoveJournal(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "/b", journal.To)
+	assert.True(t, journal.Moved)
+}
@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// workspacePruneCmd removes other people's solutions from the workspace.
+var workspacePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove other people's solutions from your workspace.",
+	Long: `Remove other people's solutions from your workspace.
+
+Solutions downloaded with "download --uuid" are stored in users/<handle>
+within the workspace. This removes them, optionally limited to solutions
+downloaded more than --older-than ago (e.g. 30d or 12h), by the person
+with the given --handle, or in the given --track.
+
+Your own exercises are never removed.
+
+Use --dry-run to see what would be removed, and how much space that would free.
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd.Flags(), false)
+		if err != nil {
+			return err
+		}
+
+		return runWorkspacePrune(cfg, cmd.Flags(), args)
+	},
+}
+
+func runWorkspacePrune(cfg config.Config, flags *pflag.FlagSet, args []string) error {
+	usrCfg := cfg.UserViperConfig
+	if usrCfg.GetString("workspace") == "" {
+		return fmt.Errorf(msgRerunConfigure, BinaryName)
+	}
+
+	olderThan, err := flags.GetString("older-than")
+	if err != nil {
+		return err
+	}
+	var minAge time.Duration
+	if olderThan != "" {
+		if minAge, err = parseAge(olderThan); err != nil {
+			return err
+		}
+	}
+	handle, err := flags.GetString("handle")
+	if err != nil {
+		return err
+	}
+	track, err := flags.GetString("track")
+	if err != nil {
+		return err
+	}
+	dryRun, err := flags.GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	ws, err := workspace.New(usrCfg.GetString("workspace"))
+	if err != nil {
+		return err
+	}
+	exercises, err := ws.CommunityExercises()
+	if err != nil {
+		return err
+	}
+
+	usersDir := filepath.Join(ws.Dir, "users")
+	var count int
+	var freed int64
+	for _, exercise := range exercises {
+		metadata, err := workspace.NewExerciseMetadata(exercise.Filepath())
+		if err != nil {
+			return err
+		}
+		// Never touch the user's own work, wherever it ended up.
+		if metadata.IsRequester {
+			continue
+		}
+		if handle != "" && metadata.Handle != handle {
+			continue
+		}
+		if track != "" && exercise.Track != track {
+			continue
+		}
+		if minAge > 0 {
+			downloadedAt, err := downloadTime(metadata, exercise)
+			if err != nil {
+				return err
+			}
+			if time.Since(downloadedAt) < minAge {
+				continue
+			}
+		}
+
+		size, err := dirSize(exercise.Filepath())
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Fprintf(Out, "Would remove %s (%s)\n", exercise.Filepath(), formatBytes(size))
+		} else {
+			if err := os.RemoveAll(exercise.Filepath()); err != nil {
+				return err
+			}
+			removeEmptyParents(filepath.Dir(exercise.Filepath()), usersDir)
+			fmt.Fprintf(Out, "Removed %s (%s)\n", exercise.Filepath(), formatBytes(size))
+		}
+		count++
+		freed += size
+	}
+
+	if dryRun {
+		fmt.Fprintf(Out, "\nWould remove %d solutions, freeing %s\n", count, formatBytes(freed))
+		return nil
+	}
+	fmt.Fprintf(Out, "\nRemoved %d solutions, freeing %s\n", count, formatBytes(freed))
+	return nil
+}
+
+// downloadTime is when the solution was downloaded. Older versions of the CLI didn't record it,
+// so then the best guess is when the metadata was last written, which is usually at the download.
+func downloadTime(metadata *workspace.ExerciseMetadata, exercise workspace.Exercise) (time.Time, error) {
+	if metadata.DownloadedAt != nil {
+		return *metadata.DownloadedAt, nil
+	}
+	info, err := os.Stat(exercise.MetadataFilepath())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// parseAge parses a duration, which may also be given in days, e.g. "30d".
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q, use e.g. 30d or 12h", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q, use e.g. 30d or 12h", s)
+	}
+	return d, nil
+}
+
+// dirSize adds up the sizes of the files in a directory tree.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// removeEmptyParents removes dir and its parents while they are empty, up to but not including stop.
+func removeEmptyParents(dir, stop string) {
+	for dir != stop && strings.HasPrefix(dir, stop) {
+		// Remove fails on a directory that isn't empty.
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// formatBytes formats a size for humans, in binary units, e.g. 1.5 MiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func setupWorkspacePruneFlags(flags *pflag.FlagSet) {
+	flags.StringP("older-than", "", "", "only remove solutions downloaded longer ago than this, e.g. 30d")
+	flags.StringP("handle", "", "", "only remove the solutions of the person with this handle")
+	flags.StringP("track", "t", "", "only remove the solutions in this track")
+	flags.BoolP("dry-run", "n", false, "show what would be removed, without removing anything")
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspacePruneCmd)
+	setupWorkspacePruneFlags(workspacePruneCmd.Flags())
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/workspace"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+// downloadedAgo is the download time of a solution that was downloaded age ago.
+func downloadedAgo(age time.Duration) *time.Time {
+	downloadedAt := time.Now().Add(-age)
+	return &downloadedAt
+}
+
+func setupWorkspacePruneTest(t *testing.T) (string, config.Config) {
+	return setupTestWorkspace(t,
+		workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "mine", IsRequester: true, DownloadedAt: downloadedAgo(0)},
+		workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "old", Handle: "alice", DownloadedAt: downloadedAgo(60 * 24 * time.Hour)},
+		workspace.ExerciseMetadata{Track: "other-track", ExerciseSlug: "new", Handle: "alice", DownloadedAt: downloadedAgo(time.Hour)},
+		workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "old", Handle: "bob", DownloadedAt: downloadedAgo(60 * 24 * time.Hour)},
+	)
+}
+
+func TestWorkspacePrune(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	tmpDir, cfg := setupWorkspacePruneTest(t)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupWorkspacePruneFlags(flags)
+
+	err := runWorkspacePrune(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	assert.DirExists(t, filepath.Join(tmpDir, "bogus-track", "mine"))
+	assert.NoDirExists(t, filepath.Join(tmpDir, "users", "alice"))
+	assert.NoDirExists(t, filepath.Join(tmpDir, "users", "bob"))
+	assert.DirExists(t, filepath.Join(tmpDir, "users"))
+	assert.Contains(t, Out.(*bytes.Buffer).String(), "Removed 3 solutions, freeing")
+}
+
+func TestWorkspacePruneFilters(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	tmpDir, cfg := setupWorkspacePruneTest(t)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupWorkspacePruneFlags(flags)
+	err := flags.Set("older-than", "30d")
+	assert.NoError(t, err)
+	err = flags.Set("handle", "alice")
+	assert.NoError(t, err)
+	err = flags.Set("track", "bogus-track")
+	assert.NoError(t, err)
+
+	err = runWorkspacePrune(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(tmpDir, "users", "alice", "bogus-track"))
+	assert.DirExists(t, filepath.Join(tmpDir, "users", "alice", "other-track", "new"))
+	assert.DirExists(t, filepath.Join(tmpDir, "users", "bob", "bogus-track", "old"))
+	assert.Contains(t, Out.(*bytes.Buffer).String(), "Removed 1 solutions, freeing")
+}
+
+func TestWorkspacePruneWithoutDownloadTime(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	tmpDir, cfg := setupWorkspacePruneTest(t)
+	// Metadata written by older versions of the CLI has no download time.
+	dir := filepath.Join(tmpDir, "users", "carol", "bogus-track", "legacy")
+	writeTestExercise(t, dir, workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "legacy", Handle: "carol"})
+	longAgo := time.Now().Add(-60 * 24 * time.Hour)
+	err := os.Chtimes(filepath.Join(dir, ".exercism", "metadata.json"), longAgo, longAgo)
+	assert.NoError(t, err)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupWorkspacePruneFlags(flags)
+	err = flags.Set("older-than", "30d")
+	assert.NoError(t, err)
+	err = flags.Set("handle", "carol")
+	assert.NoError(t, err)
+
+	err = runWorkspacePrune(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.NoDirExists(t, dir)
+}
+
+func TestWorkspacePruneDryRun(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	tmpDir, cfg := setupWorkspacePruneTest(t)
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupWorkspacePruneFlags(flags)
+	err := flags.Set("dry-run", "true")
+	assert.NoError(t, err)
+
+	err = runWorkspacePrune(cfg, flags, []string{})
+	assert.NoError(t, err)
+
+	assert.DirExists(t, filepath.Join(tmpDir, "users", "alice", "bogus-track", "old"))
+	assert.DirExists(t, filepath.Join(tmpDir, "users", "bob", "bogus-track", "old"))
+	assert.Contains(t, Out.(*bytes.Buffer).String(), "Would remove 3 solutions, freeing")
+}
+
+func TestWorkspacePruneKeepsOwnExercises(t *testing.T) {
+	co := newCapturedOutput()
+	co.override()
+	defer co.reset()
+	Out = &bytes.Buffer{}
+
+	tmpDir, cfg := setupWorkspacePruneTest(t)
+	// The user's own solution, misplaced among the community solutions.
+	dir := filepath.Join(tmpDir, "users", "carol", "bogus-track", "mine")
+	writeTestExercise(t, dir, workspace.ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "mine", Handle: "carol", IsRequester: true})
+
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	setupWorkspacePruneFlags(flags)
+
+	err := runWorkspacePrune(cfg, flags, []string{})
+	assert.NoError(t, err)
+	assert.DirExists(t, dir)
+}
+
+func TestParseAge(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"30d", 30 * 24 * time.Hour, true},
+		{"12h", 12 * time.Hour, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"d", 0, false},
+		{"-2d", 0, false},
+		{"week", 0, false},
+	}
+	for _, tc := range testCases {
+		age, err := parseAge(tc.input)
+		if !tc.ok {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		assert.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, age, tc.input)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 GiB", formatBytes(2<<30))
+}
@@ -27,6 +27,20 @@ type Config struct {
 	DefaultDirName  string
 	UserViperConfig *viper.Viper
 	Persister       Persister
+	// File is the user config file.
+	File string
+	// Profile is the name of the selected profile, if any.
+	Profile string
+	// sources records where the value of each key came from, see Load.
+	sources map[string]string
+	// fileValues and profileValues are the values read from the user config file and the profile,
+	// and overrides the values of flags, environment variables and the credential helper
+	// that were laid over them.
+	fileValues    map[string]interface{}
+	profileValues map[string]interface{}
+	overrides     map[string]interface{}
+	// unset are the keys to remove when the config is saved.
+	unset []string
 }
 
 // NewConfig provides a configuration with default values.
@@ -41,6 +55,8 @@ func NewConfig() Config {
 		DefaultBaseURL: defaultBaseURL,
 		DefaultDirName: DefaultDirName,
 		Persister:      FilePersister{Dir: dir},
+		File:           filepath.Join(dir, "user.json"),
+		Profile:        ActiveProfile(),
 	}
 }
 
@@ -111,8 +127,16 @@ func DefaultWorkspaceDir(cfg Config) string {
 }
 
 // Save persists a viper config of the base name.
+// The user config is saved without the values that override it, see Load.
 func (c Config) Save(basename string) error {
-	return c.Persister.Save(c.UserViperConfig, basename)
+	if basename == "user" && c.sources != nil {
+		return c.saveUser()
+	}
+	v := c.UserViperConfig
+	for _, key := range c.unset {
+		v = withoutKey(v, key)
+	}
+	return c.Persister.Save(v, basename)
 }
 
 // InferSiteURL guesses what the website URL is.
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CredentialHelper is an external program that keeps the API token, e.g. in a password manager or keyring,
+// instead of the user config file.
+//
+// It speaks the protocol of git's credential helpers, so that those can be used too.
+// The helper is called with an action, get, store or erase, as its last argument,
+// and is given the credential on stdin, as key=value lines ending with a blank line:
+//
+//	protocol=https
+//	host=api.exercism.org
+//	username=default
+//	password=<the token>
+//
+// The password is only given to store and erase. The username is the name of the profile.
+// For get, the helper prints the password line, or nothing if it doesn't know the token.
+type CredentialHelper struct {
+	// Command is the program and its arguments, separated by spaces.
+	Command string
+}
+
+// Credential identifies a token for the helper.
+type Credential struct {
+	Protocol string
+	Host     string
+	Username string
+	Password string
+}
+
+// Get asks the helper for the token, and returns an empty string if it doesn't have one.
+func (h CredentialHelper) Get(cred Credential) (string, error) {
+	cred.Password = ""
+	out, err := h.run("get", cred)
+	if err != nil {
+		return "", err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "password="); ok {
+			return value, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// Store hands the token to the helper.
+func (h CredentialHelper) Store(cred Credential) error {
+	_, err := h.run("store", cred)
+	return err
+}
+
+// Erase tells the helper to forget the token.
+func (h CredentialHelper) Erase(cred Credential) error {
+	_, err := h.run("erase", cred)
+	return err
+}
+
+func (h CredentialHelper) run(action string, cred Credential) ([]byte, error) {
+	args := strings.Fields(h.Command)
+	if len(args) == 0 {
+		return nil, errors.New("no credential helper configured")
+	}
+	var in bytes.Buffer
+	for _, field := range [][2]string{
+		{"protocol", cred.Protocol},
+		{"host", cred.Host},
+		{"username", cred.Username},
+		{"password", cred.Password},
+	} {
+		if field[1] != "" {
+			fmt.Fprintf(&in, "%s=%s\n", field[0], field[1])
+		}
+	}
+	in.WriteString("\n")
+
+	cmd := exec.Command(args[0], append(args[1:], action)...)
+	cmd.Stdin = &in
+	// The helper may need to ask for a passphrase.
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper '%s %s' failed: %w", h.Command, action, err)
+	}
+	return out, nil
+}
+
+// CredentialHelper is the configured credential helper, or nil if the token is kept in the user config file.
+func (c Config) CredentialHelper() *CredentialHelper {
+	command := strings.TrimSpace(c.UserViperConfig.GetString("credentialhelper"))
+	if command == "" {
+		return nil
+	}
+	return &CredentialHelper{Command: command}
+}
+
+// Credential identifies the token of the configured API, and the selected profile.
+func (c Config) Credential() Credential {
+	baseURL := c.UserViperConfig.GetString("apibaseurl")
+	if baseURL == "" {
+		baseURL = c.DefaultBaseURL
+	}
+	cred := Credential{Username: c.Profile}
+	if cred.Username == "" {
+		cred.Username = DefaultProfile
+	}
+	if u, err := url.Parse(baseURL); err == nil {
+		cred.Protocol = u.Scheme
+		cred.Host = u.Host
+	}
+	return cred
+}
+
+// LoadCredentials gets the token from the credential helper, if one is configured.
+// The helper keeps the token in place of the profile and the user config file,
+// so it overrides the token there, but not one from EXERCISM_TOKEN.
+func (c *Config) LoadCredentials() error {
+	helper := c.CredentialHelper()
+	if helper == nil {
+		return nil
+	}
+	if _, ok := c.overrides["token"]; ok {
+		return nil
+	}
+	token, err := helper.Get(c.Credential())
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return nil
+	}
+	if c.sources == nil {
+		// The config wasn't loaded with Load, so there are no sources to keep track of.
+		c.UserViperConfig.Set("token", token)
+		return nil
+	}
+	c.override("token", token, SourceCredentialHelper)
+	return nil
+}
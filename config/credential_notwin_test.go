@@ -0,0 +1,131 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCredentialHelper keeps the password in a file next to the script,
+// and records the input of the last call.
+const fakeCredentialHelper = `#!/bin/sh
+dir=$(dirname "$0")
+case "$1" in
+get)
+	cat > "$dir/input"
+	if [ -f "$dir/store" ]; then cat "$dir/store"; fi
+	;;
+store)
+	tee "$dir/input" | grep '^password=' > "$dir/store"
+	;;
+erase)
+	cat > "$dir/input"
+	rm -f "$dir/store"
+	;;
+esac
+`
+
+func writeFakeCredentialHelper(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "credential-helper")
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "helper"), []byte(fakeCredentialHelper), os.FileMode(0755))
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestCredentialHelper(t *testing.T) {
+	dir := writeFakeCredentialHelper(t)
+	defer os.RemoveAll(dir)
+
+	helper := CredentialHelper{Command: filepath.Join(dir, "helper")}
+	cred := Credential{Protocol: "https", Host: "api.exercism.org", Username: "default"}
+
+	token, err := helper.Get(cred)
+	assert.NoError(t, err)
+	assert.Equal(t, "", token)
+
+	cred.Password = "the-token"
+	err = helper.Store(cred)
+	assert.NoError(t, err)
+	input, err := os.ReadFile(filepath.Join(dir, "input"))
+	assert.NoError(t, err)
+	assert.Equal(t, "protocol=https\nhost=api.exercism.org\nusername=default\npassword=the-token\n\n", string(input))
+
+	token, err = helper.Get(cred)
+	assert.NoError(t, err)
+	assert.Equal(t, "the-token", token)
+	input, err = os.ReadFile(filepath.Join(dir, "input"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(input), "password")
+
+	err = helper.Erase(cred)
+	assert.NoError(t, err)
+	token, err = helper.Get(cred)
+	assert.NoError(t, err)
+	assert.Equal(t, "", token)
+}
+
+func TestCredentialHelperFailure(t *testing.T) {
+	helper := CredentialHelper{Command: "false"}
+	_, err := helper.Get(Credential{})
+	if assert.Error(t, err) {
+		assert.Regexp(t, "credential helper 'false get' failed", err.Error())
+	}
+}
+
+func TestLoadCredentials(t *testing.T) {
+	dir := writeFakeCredentialHelper(t)
+	defer os.RemoveAll(dir)
+	err := os.WriteFile(filepath.Join(dir, "store"), []byte("password=helper-token\n"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.Set("token", "")
+	v.Set("apibaseurl", "http://localhost:3000/api/v1")
+	v.Set("credentialhelper", filepath.Join(dir, "helper"))
+	cfg := Config{UserViperConfig: v, Profile: "staging"}
+
+	err = cfg.LoadCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "helper-token", v.GetString("token"))
+
+	input, err := os.ReadFile(filepath.Join(dir, "input"))
+	assert.NoError(t, err)
+	assert.Equal(t, "protocol=http\nhost=localhost:3000\nusername=staging\n\n", string(input))
+}
+
+func TestLoadCredentialsKeepsEnvToken(t *testing.T) {
+	dir := writeFakeCredentialHelper(t)
+	defer os.RemoveAll(dir)
+	err := os.WriteFile(filepath.Join(dir, "store"), []byte("password=helper-token\n"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	configDir := t.TempDir()
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+	t.Setenv("EXERCISM_CREDENTIAL_HELPER", filepath.Join(dir, "helper"))
+	t.Setenv("EXERCISM_TOKEN", "env-token")
+
+	cfg := newLoadTestConfig(configDir)
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	err = cfg.LoadCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-token", cfg.UserViperConfig.GetString("token"))
+	assert.Equal(t, "EXERCISM_TOKEN", cfg.Source("token"))
+	assert.NoFileExists(t, filepath.Join(dir, "input"), "the helper isn't asked")
+
+	t.Setenv("EXERCISM_TOKEN", "")
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	err = cfg.LoadCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "helper-token", cfg.UserViperConfig.GetString("token"))
+	assert.Equal(t, SourceCredentialHelper, cfg.Source("token"))
+}
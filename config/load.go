@@ -0,0 +1,296 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/exercism/cli/atomicfile"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// EnvVars are the environment variables that override the user config, by key.
+var EnvVars = map[string]string{
+	"token":            "EXERCISM_TOKEN",
+	"workspace":        "EXERCISM_WORKSPACE",
+	"apibaseurl":       "EXERCISM_API_BASE_URL",
+	"autocommit":       "EXERCISM_AUTOCOMMIT",
+	"credentialhelper": "EXERCISM_CREDENTIAL_HELPER",
+	"cafile":           "EXERCISM_CA_FILE",
+	"clientcert":       "EXERCISM_CLIENT_CERT",
+	"clientkey":        "EXERCISM_CLIENT_KEY",
+	"mintlsversion":    "EXERCISM_MIN_TLS_VERSION",
+}
+
+// FlagKeys are the user config keys that global flags override, by flag name.
+// The other keys are only overridden by environment variables: a token given as a flag
+// would end up in the shell history and the process list, and the rest are set once
+// for a machine or a CI job rather than for a single command.
+var FlagKeys = map[string]string{
+	"workspace": "workspace",
+	"api":       "apibaseurl",
+}
+
+// Where the value of a user config key came from, unless it came from a file.
+const (
+	SourceDefault          = "default"
+	SourceCredentialHelper = "credential helper"
+)
+
+// Load reads the user config. The value of each key is taken from the first of:
+//
+//  1. a global flag, e.g. --workspace (see FlagKeys)
+//  2. an environment variable, e.g. EXERCISM_TOKEN (see EnvVars)
+//  3. the selected profile, from --profile or EXERCISM_PROFILE,
+//     or for the token, the credential helper (see LoadCredentials)
+//  4. the user config file, user.json in the config dir or the file given with --config
+//  5. the default
+//
+// Saving the config never writes the values of flags and environment variables,
+// unless the command changed them.
+//
+// A user config file in an older format is migrated, see UserConfigVersion.
+// The file itself is only migrated when the config is saved.
+//
+// If createProfile is true, a profile that doesn't exist yet starts out with the values of the user config.
+func Load(flags *pflag.FlagSet, createProfile bool) (Config, error) {
+	c := NewConfig()
+	if path, ok := changedFlag(flags, "config"); ok {
+		if filepath.Ext(path) != ".json" {
+			return c, fmt.Errorf("the config file %s must be a .json file", path)
+		}
+		c.File = Resolve(path, c.Home)
+	}
+	if name, ok := changedFlag(flags, "profile"); ok {
+		c.Profile = name
+		if name == DefaultProfile {
+			c.Profile = ""
+		}
+	}
+	return c, c.load(flags, createProfile)
+}
+
+func (c *Config) load(flags *pflag.FlagSet, createProfile bool) error {
+	v := viper.New()
+	v.SetConfigFile(c.File)
+	v.SetConfigType("json")
+	// If the file doesn't exist, that is fine. There is nothing to migrate either.
+	if err := v.ReadInConfig(); err == nil && v.GetInt(VersionKey) != UserConfigVersion {
+		settings, err := c.migrate(v.AllSettings())
+		if err != nil {
+			return err
+		}
+		v = viper.New()
+		for key, value := range settings {
+			v.Set(key, value)
+		}
+		v.SetConfigFile(c.File)
+		v.SetConfigType("json")
+	}
+	c.UserViperConfig = v
+
+	c.sources = map[string]string{}
+	c.overrides = map[string]interface{}{}
+	c.fileValues = v.AllSettings()
+	for key := range c.fileValues {
+		c.sources[key] = c.File
+	}
+	if c.DefaultBaseURL != "" && !v.IsSet("apibaseurl") {
+		v.SetDefault("apibaseurl", c.DefaultBaseURL)
+		c.sources["apibaseurl"] = SourceDefault
+	}
+
+	if err := c.loadProfile(createProfile); err != nil {
+		return err
+	}
+
+	for key, env := range EnvVars {
+		if value := os.Getenv(env); value != "" {
+			c.override(key, value, env)
+		}
+	}
+	for name, key := range FlagKeys {
+		if value, ok := changedFlag(flags, name); ok {
+			c.override(key, value, "--"+name)
+		}
+	}
+	return nil
+}
+
+func (c *Config) override(key, value, source string) {
+	if setting, ok := LookupSetting(key); ok && setting.Type == TypePath {
+		value = Resolve(value, c.Home)
+	}
+	c.UserViperConfig.Set(key, value)
+	c.overrides[key] = value
+	c.sources[key] = source
+}
+
+// Source describes where the value of the key came from: a file, a flag, an environment variable,
+// the credential helper, or the default. It is empty if the key isn't set.
+// A key within a map, e.g. test_commands.go, has the source of the map.
+func (c Config) Source(key string) string {
+	for {
+		if source, ok := c.sources[key]; ok {
+			return source
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return ""
+		}
+		key = key[:i]
+	}
+}
+
+// Unset removes the key from the user config. With a profile selected, a profile key
+// is removed from the profile, so that the value of the user config file applies again.
+func (c *Config) Unset(key string) {
+	c.unset = append(c.unset, strings.ToLower(key))
+}
+
+func changedFlag(flags *pflag.FlagSet, name string) (string, bool) {
+	if flags == nil {
+		return "", false
+	}
+	flag := flags.Lookup(name)
+	if flag == nil || !flag.Changed {
+		return "", false
+	}
+	return flag.Value.String(), true
+}
+
+// saveUser persists the user config, leaving out the values of flags and environment variables,
+// and the token from the credential helper. With a profile selected, the profile values go to
+// the profile, and the rest to the user config file.
+func (c Config) saveUser() error {
+	file := viper.New()
+	profile := viper.New()
+	for key, value := range c.UserViperConfig.AllSettings() {
+		inProfile := c.Profile != "" && isProfileKey(key)
+		if override, ok := c.overrides[key]; ok && reflect.DeepEqual(value, override) {
+			// Keep whatever the override hid.
+			if !inProfile {
+				if fileValue, ok := c.fileValues[key]; ok {
+					file.Set(key, fileValue)
+				}
+			} else if profileValue, ok := c.profileValues[key]; ok {
+				profile.Set(key, profileValue)
+			}
+			continue
+		}
+		if inProfile {
+			profile.Set(key, value)
+		} else {
+			file.Set(key, value)
+		}
+	}
+
+	file.Set(VersionKey, UserConfigVersion)
+
+	for _, key := range c.unset {
+		if c.Profile != "" && isProfileKey(key) {
+			profile = withoutKey(profile, key)
+		} else {
+			file = withoutKey(file, key)
+		}
+	}
+
+	persister, basename := c.Persister, "user"
+	if c.File != filepath.Join(c.Dir, "user.json") {
+		persister = FilePersister{Dir: filepath.Dir(c.File)}
+		basename = strings.TrimSuffix(filepath.Base(c.File), ".json")
+	}
+	if c.Profile == "" {
+		return saveChanges(persister, basename, c.File, file, c.fileValues, c.migrateFile)
+	}
+
+	// The user config file keeps its own values of the profile keys.
+	for _, key := range ProfileKeys {
+		if value, ok := c.fileValues[key]; ok {
+			file.Set(key, value)
+		}
+	}
+	if err := saveChanges(persister, basename, c.File, file, c.fileValues, c.migrateFile); err != nil {
+		return err
+	}
+	return saveChanges(c.Persister, profileBasename(c.Profile), profilePath(c.Dir, c.Profile), profile, c.profileValues, nil)
+}
+
+// saveChanges saves the settings to the file at path. Another CLI may have changed the file
+// since it was loaded, e.g. an editor plugin running alongside the terminal. So with the file
+// locked, only the keys whose values differ from the loaded ones are applied to the file as it is now.
+// If migrate isn't nil, it brings the file as it is now to the format of the loaded settings first.
+func saveChanges(persister Persister, basename, path string, settings *viper.Viper, loaded map[string]interface{}, migrate func(*viper.Viper) (*viper.Viper, error)) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0700)); err != nil {
+		return err
+	}
+	unlock, err := atomicfile.Lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current := viper.New()
+	current.SetConfigFile(path)
+	current.SetConfigType("json")
+	// Ignore error. If the file doesn't exist, that is fine.
+	_ = current.ReadInConfig()
+	if migrate != nil {
+		if current, err = migrate(current); err != nil {
+			return err
+		}
+	}
+
+	desired, previous := flatten(settings.AllSettings()), flatten(loaded)
+	for key, value := range desired {
+		if loadedValue, ok := previous[key]; !ok || !reflect.DeepEqual(value, loadedValue) {
+			current.Set(key, value)
+		}
+	}
+	for key := range previous {
+		if _, ok := desired[key]; !ok {
+			current = withoutKey(current, key)
+		}
+	}
+	return persister.Save(current, basename)
+}
+
+// flatten turns nested settings into dotted keys, e.g. test_commands.go, so that changes
+// to different keys within a map don't replace each other.
+func flatten(settings map[string]interface{}) map[string]interface{} {
+	flat := map[string]interface{}{}
+	for key, value := range settings {
+		if m, ok := value.(map[string]interface{}); ok && len(m) > 0 {
+			for k, v := range flatten(m) {
+				flat[key+"."+k] = v
+			}
+			continue
+		}
+		flat[key] = value
+	}
+	return flat
+}
+
+// withoutKey copies the settings of v, leaving out the key, since viper can't unset a key.
+func withoutKey(v *viper.Viper, key string) *viper.Viper {
+	settings := v.AllSettings()
+	parts := strings.Split(key, ".")
+	m := settings
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			return v
+		}
+		m = next
+	}
+	delete(m, parts[len(parts)-1])
+
+	w := viper.New()
+	for k, value := range settings {
+		w.Set(k, value)
+	}
+	return w
+}
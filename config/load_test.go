@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLoadTestConfig(dir string) Config {
+	return Config{
+		Dir:       dir,
+		Home:      dir,
+		File:      filepath.Join(dir, "user.json"),
+		Persister: FilePersister{Dir: dir},
+	}
+}
+
+func newLoadTestFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("fake", pflag.PanicOnError)
+	flags.String("config", "", "")
+	flags.String("profile", "", "")
+	flags.String("workspace", "", "")
+	flags.String("api", "", "")
+	err := flags.Parse(args)
+	assert.NoError(t, err)
+	return flags
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir, err := os.MkdirTemp("", "load")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"token": "file-token", "workspace": "/file-workspace", "autocommit": "track"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+	writeTestProfile(t, dir, "staging", `{"token": "profile-token", "workspace": "/profile-workspace", "apibaseurl": "http://profile.example.com"}`)
+	t.Setenv("EXERCISM_TOKEN", "env-token")
+	t.Setenv("EXERCISM_WORKSPACE", "/env-workspace")
+	flags := newLoadTestFlags(t, "--workspace", "/flag-workspace")
+
+	cfg := newLoadTestConfig(dir)
+	cfg.DefaultBaseURL = "http://default.example.com"
+	cfg.Profile = "staging"
+	err = cfg.load(flags, false)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		key, value, source string
+	}{
+		{"token", "env-token", "EXERCISM_TOKEN"},
+		{"workspace", "/flag-workspace", "--workspace"},
+		{"apibaseurl", "http://profile.example.com", filepath.Join(dir, "profiles", "staging.json")},
+		{"autocommit", "track", filepath.Join(dir, "user.json")},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.value, cfg.UserViperConfig.GetString(tc.key), tc.key)
+		assert.Equal(t, tc.source, cfg.Source(tc.key), tc.key)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	dir, err := os.MkdirTemp("", "load")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+
+	cfg := newLoadTestConfig(dir)
+	cfg.DefaultBaseURL = "http://default.example.com"
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://default.example.com", cfg.UserViperConfig.GetString("apibaseurl"))
+	assert.Equal(t, SourceDefault, cfg.Source("apibaseurl"))
+	assert.Equal(t, "", cfg.Source("token"))
+}
+
+func TestSaveWithoutOverrides(t *testing.T) {
+	dir, err := os.MkdirTemp("", "load")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"token": "file-token", "workspace": "/file-workspace"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+	t.Setenv("EXERCISM_TOKEN", "env-token")
+	t.Setenv("EXERCISM_API_BASE_URL", "http://env.example.com")
+	flags := newLoadTestFlags(t, "--workspace", "/flag-workspace")
+
+	cfg := newLoadTestConfig(dir)
+	err = cfg.load(flags, false)
+	assert.NoError(t, err)
+	// A value that the command changes is saved, even if it was overridden.
+	cfg.UserViperConfig.Set("apibaseurl", "http://changed.example.com")
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(dir, "user.json"))
+	err = v.ReadInConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", v.GetString("token"))
+	assert.Equal(t, "/file-workspace", v.GetString("workspace"))
+	assert.Equal(t, "http://changed.example.com", v.GetString("apibaseurl"))
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "load")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+	t.Setenv("EXERCISM_CONFIG_HOME", filepath.Join(dir, "config"))
+
+	path := filepath.Join(dir, "ci.json")
+	err = os.WriteFile(path, []byte(`{"token": "ci-token"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	cfg, err := Load(newLoadTestFlags(t, "--config", path), false)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-token", cfg.UserViperConfig.GetString("token"))
+	assert.Equal(t, path, cfg.Source("token"))
+
+	cfg.UserViperConfig.Set("workspace", "/ci-workspace")
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "/ci-workspace")
+	assert.NoFileExists(t, filepath.Join(dir, "config", "user.json"))
+
+	_, err = Load(newLoadTestFlags(t, "--config", filepath.Join(dir, "ci.yaml")), false)
+	assert.Error(t, err)
+}
+
+func TestUnset(t *testing.T) {
+	dir, err := os.MkdirTemp("", "load")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"workspace": "/file-workspace", "autocommit": "track", "test_commands": {"go": "go test", "ruby": "rake"}}`), os.FileMode(0600))
+	assert.NoError(t, err)
+	writeTestProfile(t, dir, "staging", `{"workspace": "/profile-workspace"}`)
+
+	cfg := newLoadTestConfig(dir)
+	cfg.Profile = "staging"
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "user.json"), cfg.Source("test_commands.go"))
+
+	cfg.Unset("workspace")
+	cfg.Unset("autocommit")
+	cfg.Unset("test_commands.go")
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+
+	profile, err := ReadProfile(dir, "staging")
+	assert.NoError(t, err)
+	assert.False(t, profile.IsSet("workspace"))
+
+	usr := viper.New()
+	usr.SetConfigFile(filepath.Join(dir, "user.json"))
+	err = usr.ReadInConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "/file-workspace", usr.GetString("workspace"))
+	assert.False(t, usr.IsSet("autocommit"))
+	assert.False(t, usr.IsSet("test_commands.go"))
+	assert.Equal(t, "rake", usr.GetString("test_commands.ruby"))
+}
+
+func TestSaveWithParallelWriters(t *testing.T) {
+	dir := t.TempDir()
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+	err := os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"version": 1, "token": "abc123"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	// Each writer loads the config before any of them saves it.
+	const writers = 8
+	cfgs := make([]Config, writers)
+	for i := range cfgs {
+		cfgs[i] = newLoadTestConfig(dir)
+		err := cfgs[i].load(nil, false)
+		assert.NoError(t, err)
+		cfgs[i].UserViperConfig.Set(fmt.Sprintf("test_commands.track%d", i), "make test")
+	}
+	cfgs[0].UserViperConfig.Set("workspace", "/workspace")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for _, cfg := range cfgs {
+		wg.Add(1)
+		go func(cfg Config) {
+			defer wg.Done()
+			errs <- cfg.Save("user")
+		}(cfg)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(dir, "user.json"))
+	err = v.ReadInConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", v.GetString("token"))
+	assert.Equal(t, "/workspace", v.GetString("workspace"))
+	assert.Len(t, v.GetStringMapString("test_commands"), writers)
+}
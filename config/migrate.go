@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/exercism/cli/atomicfile"
+	"github.com/spf13/viper"
+)
+
+// UserConfigVersion is the version of the user config format that this CLI writes.
+// Bump it along with a new migration whenever the format changes.
+const UserConfigVersion = 1
+
+// VersionKey is the user config key that holds the format version.
+// A file without it is version 0, from before the format was versioned.
+const VersionKey = "version"
+
+// ErrConfigTooNew is the error for a user config file that was written by a newer version of the CLI,
+// in a format this version doesn't understand.
+type ErrConfigTooNew struct {
+	// File is the user config file.
+	File string
+	// Version is the format version of the file.
+	Version int
+	// Supported is the newest format version this CLI understands, i.e. UserConfigVersion.
+	Supported int
+}
+
+func (e *ErrConfigTooNew) Error() string {
+	return fmt.Sprintf("the config file %s was written by a newer version of the CLI (format version %d, this version understands %d or older)", e.File, e.Version, e.Supported)
+}
+
+// migrations upgrade the settings of the user config, one version at a time:
+// migrations[i] turns version i into version i+1.
+var migrations = []func(settings map[string]interface{}, home string){
+	migrateToV1,
+}
+
+// migrateToV1 cleans up values that older versions wrote or that were edited by hand:
+// empty values (e.g. the token left behind by configuring a credential helper),
+// a workspace relative to the home directory, and a trailing slash on the API base URL.
+func migrateToV1(settings map[string]interface{}, home string) {
+	removeEmptyValues(settings)
+	if workspace, ok := settings["workspace"].(string); ok && strings.HasPrefix(workspace, "~/") {
+		settings["workspace"] = Resolve(workspace, home)
+	}
+	if url, ok := settings["apibaseurl"].(string); ok {
+		settings["apibaseurl"] = strings.TrimRight(url, "/")
+	}
+}
+
+func removeEmptyValues(settings map[string]interface{}) {
+	for key, value := range settings {
+		switch value := value.(type) {
+		case string:
+			if value == "" {
+				delete(settings, key)
+			}
+		case map[string]interface{}:
+			removeEmptyValues(value)
+		}
+	}
+}
+
+// migrate brings the settings that were read from the user config file up to the current version.
+// This only happens in memory, so that commands which just read the config don't write it.
+// The file itself is migrated when the config is saved, see migrateFile.
+// It refuses settings written by a newer CLI, since it can't know what has changed.
+func (c Config) migrate(settings map[string]interface{}) (map[string]interface{}, error) {
+	version, _ := settings[VersionKey].(int)
+	if f, ok := settings[VersionKey].(float64); ok {
+		version = int(f)
+	}
+	if version > UserConfigVersion {
+		return nil, &ErrConfigTooNew{File: c.File, Version: version, Supported: UserConfigVersion}
+	}
+	for ; version < UserConfigVersion; version++ {
+		migrations[version](settings, c.Home)
+	}
+	settings[VersionKey] = UserConfigVersion
+	return settings, nil
+}
+
+// migrateFile migrates the user config file as it is now, before it's saved with the file locked.
+// It backs up the original file first, next to it, e.g. as user.json.v0.bak.
+func (c Config) migrateFile(current *viper.Viper) (*viper.Viper, error) {
+	version := current.GetInt(VersionKey)
+	if version >= UserConfigVersion {
+		return current, nil
+	}
+	b, err := os.ReadFile(c.File)
+	if os.IsNotExist(err) {
+		// There is no file yet, so there is nothing to migrate.
+		return current, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", c.File, version)
+	if err := atomicfile.WriteFile(backup, b, os.FileMode(0600)); err != nil {
+		return nil, fmt.Errorf("unable to back up the config file %s before migrating it: %w", c.File, err)
+	}
+
+	settings, err := c.migrate(current.AllSettings())
+	if err != nil {
+		return nil, err
+	}
+	migrated := viper.New()
+	for key, value := range settings {
+		migrated.Set(key, value)
+	}
+	return migrated, nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateFromUnversioned(t *testing.T) {
+	dir := t.TempDir()
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+	original := `{"token": "", "workspace": "~/exercism", "apibaseurl": "http://localhost:3000/api/v1/", "test_commands": {"go": "go test", "ruby": ""}}`
+	path := filepath.Join(dir, "user.json")
+	err := os.WriteFile(path, []byte(original), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	cfg := newLoadTestConfig(dir)
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "exercism"), cfg.UserViperConfig.GetString("workspace"))
+	assert.Equal(t, "http://localhost:3000/api/v1", cfg.UserViperConfig.GetString("apibaseurl"))
+	assert.Equal(t, path, cfg.Source("workspace"))
+
+	// Loading the config doesn't write it.
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, original, string(b))
+	assert.NoFileExists(t, path+".v0.bak")
+
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+
+	backup, err := os.ReadFile(path + ".v0.bak")
+	assert.NoError(t, err)
+	assert.Equal(t, original, string(backup))
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	err = v.ReadInConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, UserConfigVersion, v.GetInt(VersionKey))
+	assert.False(t, v.IsSet("token"))
+	assert.Equal(t, filepath.Join(dir, "exercism"), v.GetString("workspace"))
+	assert.Equal(t, "http://localhost:3000/api/v1", v.GetString("apibaseurl"))
+	assert.Equal(t, "go test", v.GetString("test_commands.go"))
+	assert.False(t, v.IsSet("test_commands.ruby"))
+}
+
+func TestMigrateCurrentVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.json")
+	err := os.WriteFile(path, []byte(`{"version": 1, "token": "abc123"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	cfg := newLoadTestConfig(dir)
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", cfg.UserViperConfig.GetString("token"))
+	assert.NoFileExists(t, path+".v1.bak")
+}
+
+func TestMigrateNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.json")
+	err := os.WriteFile(path, []byte(`{"version": 99}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	cfg := newLoadTestConfig(dir)
+	err = cfg.load(nil, false)
+	var tooNew *ErrConfigTooNew
+	if assert.ErrorAs(t, err, &tooNew) {
+		assert.Equal(t, ErrConfigTooNew{File: path, Version: 99, Supported: UserConfigVersion}, *tooNew)
+		assert.Contains(t, err.Error(), "written by a newer version of the CLI")
+	}
+}
+
+func TestSaveWritesVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, env := range EnvVars {
+		t.Setenv(env, "")
+	}
+
+	cfg := newLoadTestConfig(dir)
+	err := cfg.load(nil, false)
+	assert.NoError(t, err)
+	cfg.UserViperConfig.Set("token", "abc123")
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+
+	// The new file is current, so loading it doesn't migrate it.
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "user.json.v0.bak"))
+	assert.Equal(t, UserConfigVersion, cfg.UserViperConfig.GetInt(VersionKey))
+}
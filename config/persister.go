@@ -1,10 +1,12 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/exercism/cli/atomicfile"
 	"github.com/spf13/viper"
 )
 
@@ -19,24 +21,21 @@ type FilePersister struct {
 }
 
 // Save writes the viper config to the target location on the filesystem.
+// The file is replaced atomically, so a crash or a concurrent reader never sees it half-written.
 func (p FilePersister) Save(v *viper.Viper, basename string) error {
-	v.SetConfigType("json")
-	v.AddConfigPath(p.Dir)
-	v.SetConfigName(basename)
-
-	if _, err := os.Stat(p.Dir); os.IsNotExist(err) {
-		if err := os.MkdirAll(p.Dir, os.FileMode(0700)); err != nil {
+	// The base name may include a subdirectory, e.g. for profiles.
+	path := filepath.Join(p.Dir, fmt.Sprintf("%s.json", basename))
+	if _, err := os.Stat(filepath.Dir(path)); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0700)); err != nil {
 			return err
 		}
 	}
 
-	// WriteConfig is broken.
-	// Someone proposed a fix in https://github.com/spf13/viper/pull/503,
-	// but the fix doesn't work yet.
-	// When it's fixed and merged we can get rid of `path`
-	// and use viperConfig.WriteConfig() directly.
-	path := filepath.Join(p.Dir, fmt.Sprintf("%s.json", basename))
-	return v.WriteConfigAs(path)
+	b, err := json.MarshalIndent(v.AllSettings(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, b, os.FileMode(0600))
 }
 
 // InMemoryPersister is a noop persister for use in unit tests.
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ProfileEnvVar is the environment variable that selects a profile, unless the --profile flag does.
+const ProfileEnvVar = "EXERCISM_PROFILE"
+
+// DefaultProfile is the name of the configuration without a profile, i.e. the user config alone.
+const DefaultProfile = "default"
+
+// profilesDir is the directory within the config dir that holds the profiles.
+const profilesDir = "profiles"
+
+// ProfileKeys are the user config values that a profile holds.
+// The other values are shared by all profiles.
+var ProfileKeys = []string{"token", "apibaseurl", "workspace"}
+
+var rgxProfileName = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9_.-]*\z`)
+
+// ActiveProfile is the name of the profile selected with the environment variable,
+// or an empty string if none is selected. The --profile flag takes precedence, see Load.
+func ActiveProfile() string {
+	name := os.Getenv(ProfileEnvVar)
+	if name == DefaultProfile {
+		return ""
+	}
+	return name
+}
+
+// ProfileNames lists the profiles in the config dir, sorted by name.
+func ProfileNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, profilesDir))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if entry.IsDir() || name == entry.Name() || !rgxProfileName.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ProfileExists reports whether there is a profile with the name in the config dir.
+func ProfileExists(dir, name string) bool {
+	_, err := os.Stat(profilePath(dir, name))
+	return err == nil
+}
+
+func profilePath(dir, name string) string {
+	return filepath.Join(dir, profileBasename(name)+".json")
+}
+
+func profileBasename(name string) string {
+	return filepath.Join(profilesDir, name)
+}
+
+// loadProfile reads the selected profile, and lays its values over the user config.
+func (c *Config) loadProfile(create bool) error {
+	if c.Profile == "" {
+		return nil
+	}
+	if !rgxProfileName.MatchString(c.Profile) {
+		return fmt.Errorf("invalid profile name %q, use letters, digits, '.', '_' and '-'", c.Profile)
+	}
+
+	v, err := ReadProfile(c.Dir, c.Profile)
+	if os.IsNotExist(err) {
+		if create {
+			return nil
+		}
+		return fmt.Errorf("the profile %q doesn't exist, create it by running configure with --profile=%[1]s", c.Profile)
+	}
+	if err != nil {
+		return err
+	}
+	c.profileValues = map[string]interface{}{}
+	for _, key := range ProfileKeys {
+		if v.IsSet(key) {
+			c.UserViperConfig.Set(key, v.Get(key))
+			c.profileValues[key] = v.Get(key)
+			c.sources[key] = profilePath(c.Dir, c.Profile)
+		}
+	}
+	return nil
+}
+
+// ReadProfile reads the profile with the name from the config dir.
+// The error satisfies os.IsNotExist if there is no such profile.
+func ReadProfile(dir, name string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(profilePath(dir, name))
+	v.SetConfigType("json")
+	if err := v.ReadInConfig(); err != nil {
+		if os.IsNotExist(err) {
+			return nil, &os.PathError{Op: "read profile", Path: name, Err: os.ErrNotExist}
+		}
+		return nil, fmt.Errorf("invalid profile %q: %w", name, err)
+	}
+	return v, nil
+}
+
+func isProfileKey(key string) bool {
+	for _, k := range ProfileKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestProfile(t *testing.T, dir, name, contents string) {
+	err := os.MkdirAll(filepath.Join(dir, profilesDir), os.FileMode(0700))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, profilesDir, name+".json"), []byte(contents), os.FileMode(0600))
+	assert.NoError(t, err)
+}
+
+func TestActiveProfile(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	assert.Equal(t, "", ActiveProfile())
+
+	t.Setenv(ProfileEnvVar, "staging")
+	assert.Equal(t, "staging", ActiveProfile())
+
+	t.Setenv(ProfileEnvVar, DefaultProfile)
+	assert.Equal(t, "", ActiveProfile())
+}
+
+func TestProfileNames(t *testing.T) {
+	dir, err := os.MkdirTemp("", "profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	names, err := ProfileNames(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	writeTestProfile(t, dir, "work", `{}`)
+	writeTestProfile(t, dir, "staging", `{}`)
+	err = os.WriteFile(filepath.Join(dir, profilesDir, "notes.txt"), []byte("not a profile"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	names, err = ProfileNames(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"staging", "work"}, names)
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeTestProfile(t, dir, "staging", `{"token": "staging-token", "apibaseurl": "http://localhost:3000/api/v1"}`)
+
+	err = os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"token": "token", "workspace": "/workspace", "autocommit": "track"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+	cfg := newLoadTestConfig(dir)
+	cfg.Profile = "staging"
+
+	err = cfg.load(nil, false)
+	assert.NoError(t, err)
+	v := cfg.UserViperConfig
+	assert.Equal(t, "staging-token", v.GetString("token"))
+	assert.Equal(t, "http://localhost:3000/api/v1", v.GetString("apibaseurl"))
+	// Values that the profile doesn't hold come from the user config.
+	assert.Equal(t, "/workspace", v.GetString("workspace"))
+
+	v.Set("workspace", "/staging-workspace")
+	err = cfg.Save("user")
+	assert.NoError(t, err)
+
+	profile, err := ReadProfile(dir, "staging")
+	assert.NoError(t, err)
+	assert.Equal(t, "staging-token", profile.GetString("token"))
+	assert.Equal(t, "/staging-workspace", profile.GetString("workspace"))
+
+	usr := viper.New()
+	usr.SetConfigFile(filepath.Join(dir, "user.json"))
+	err = usr.ReadInConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "token", usr.GetString("token"))
+	assert.Equal(t, "/workspace", usr.GetString("workspace"))
+	assert.Equal(t, "track", usr.GetString("autocommit"))
+	assert.False(t, usr.IsSet("apibaseurl"))
+}
+
+func TestLoadMissingProfile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "profiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := newLoadTestConfig(dir)
+	cfg.Profile = "missing"
+	err = cfg.load(nil, false)
+	if assert.Error(t, err) {
+		assert.Regexp(t, "doesn't exist", err.Error())
+	}
+	assert.NoError(t, cfg.load(nil, true))
+
+	cfg.Profile = "../escape"
+	assert.Error(t, cfg.load(nil, true))
+}
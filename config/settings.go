@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SettingType is the type of the value of a setting.
+type SettingType int
+
+// SettingType
+const (
+	TypeString SettingType = iota
+	// TypePath is a file system path, which is resolved when it's set, see Resolve.
+	TypePath
+	// TypeURL is an http or https URL.
+	TypeURL
+	// TypeEnum is one of the Allowed values.
+	TypeEnum
+)
+
+func (t SettingType) String() string {
+	switch t {
+	case TypePath:
+		return "path"
+	case TypeURL:
+		return "URL"
+	case TypeEnum:
+		return "enum"
+	default:
+		return "string"
+	}
+}
+
+// Setting describes a key of the user config.
+type Setting struct {
+	// Key is the name of the setting. A key ending in ".*" stands for any key within it,
+	// e.g. test_commands.* for test_commands.go.
+	Key         string
+	Type        SettingType
+	Allowed     []string
+	Description string
+	// Secret values are redacted when they're listed.
+	Secret bool
+}
+
+// Settings are the keys of the user config that the CLI knows about.
+var Settings = []Setting{
+	{Key: "token", Type: TypeString, Secret: true, Description: "the API token"},
+	{Key: "workspace", Type: TypePath, Description: "the directory for the exercises"},
+	{Key: "apibaseurl", Type: TypeURL, Description: "the base URL of the API"},
+	{Key: "autocommit", Type: TypeEnum, Allowed: []string{"workspace", "track"}, Description: "commit exercises to a git repository per workspace or per track"},
+	{Key: "credentialhelper", Type: TypeString, Description: "the program that keeps the token"},
+	{Key: "test_commands.*", Type: TypeString, Description: "the command that runs the tests of a track"},
+	{Key: "proxy", Type: TypeURL, Description: "the proxy for all requests, instead of HTTPS_PROXY and HTTP_PROXY"},
+	{Key: "noproxy", Type: TypeString, Description: "the hosts to reach without the proxy, instead of NO_PROXY"},
+	{Key: "cafile", Type: TypePath, Description: "a PEM bundle of extra certificate authorities to trust"},
+	{Key: "clientcert", Type: TypePath, Description: "a PEM client certificate for servers that ask for one"},
+	{Key: "clientkey", Type: TypePath, Description: "the PEM key of the client certificate"},
+	{Key: "mintlsversion", Type: TypeEnum, Allowed: []string{"1.2", "1.3"}, Description: "the oldest TLS version to accept"},
+}
+
+// LookupSetting finds the setting for the key.
+func LookupSetting(key string) (Setting, bool) {
+	key = strings.ToLower(key)
+	for _, setting := range Settings {
+		if prefix, ok := strings.CutSuffix(setting.Key, "*"); ok {
+			if strings.HasPrefix(key, prefix) && len(key) > len(prefix) && !strings.Contains(key[len(prefix):], ".") {
+				return setting, true
+			}
+			continue
+		}
+		if setting.Key == key {
+			return setting, true
+		}
+	}
+	return Setting{}, false
+}
+
+// SettingKeys are the keys of the settings, sorted.
+func SettingKeys() []string {
+	keys := make([]string, 0, len(Settings))
+	for _, setting := range Settings {
+		keys = append(keys, setting.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Parse checks the value against the setting, and returns it as it should be stored.
+func (s Setting) Parse(value, home string) (string, error) {
+	switch s.Type {
+	case TypePath:
+		if value == "" {
+			return "", fmt.Errorf("%s must be a path", s.Key)
+		}
+		return Resolve(value, home), nil
+	case TypeURL:
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("%s must be an http or https URL", s.Key)
+		}
+		return strings.TrimSuffix(value, "/"), nil
+	case TypeEnum:
+		for _, allowed := range s.Allowed {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%s must be one of: %s", s.Key, strings.Join(s.Allowed, ", "))
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupSetting(t *testing.T) {
+	testCases := []struct {
+		key   string
+		found bool
+	}{
+		{"workspace", true},
+		{"Workspace", true},
+		{"test_commands.go", true},
+		{"test_commands", false},
+		{"test_commands.", false},
+		{"test_commands.go.extra", false},
+		{"nope", false},
+	}
+	for _, tc := range testCases {
+		_, ok := LookupSetting(tc.key)
+		assert.Equal(t, tc.found, ok, tc.key)
+	}
+}
+
+func TestSettingParse(t *testing.T) {
+	testCases := []struct {
+		key, value, expected string
+		valid                bool
+	}{
+		{"workspace", "~/exercism", filepath.Join("/home/alice", "exercism"), true},
+		{"workspace", "", "", false},
+		{"apibaseurl", "http://localhost:3000/api/v1/", "http://localhost:3000/api/v1", true},
+		{"apibaseurl", "localhost:3000", "", false},
+		{"apibaseurl", "ftp://example.com", "", false},
+		{"autocommit", "track", "track", true},
+		{"autocommit", "always", "", false},
+		{"test_commands.go", "go test -v", "go test -v", true},
+	}
+	for _, tc := range testCases {
+		setting, ok := LookupSetting(tc.key)
+		assert.True(t, ok, tc.key)
+		value, err := setting.Parse(tc.value, "/home/alice")
+		if !tc.valid {
+			assert.Error(t, err, tc.value)
+			continue
+		}
+		assert.NoError(t, err, tc.value)
+		assert.Equal(t, tc.expected, value, tc.value)
+	}
+}
@@ -89,7 +89,11 @@ func DumpResponse(res *http.Response) {
 }
 
 // Redact masks the given token by replacing part of the string with *
+// Tokens too short to show any of them are masked completely.
 func Redact(token string) string {
+	if len(token) < 10 {
+		return strings.Repeat("*", len(token))
+	}
 	str := token[4 : len(token)-3]
 	redaction := strings.Repeat("*", len(str))
 	return string(token[:4]) + redaction + string(token[len(token)-3:])
@@ -105,4 +105,6 @@ func TestRedact(t *testing.T) {
 	expected := "1a11*************************aa1"
 
 	assert.Equal(t, expected, Redact(fakeToken))
+	assert.Equal(t, "*****", Redact("short"))
+	assert.Equal(t, "", Redact(""))
 }
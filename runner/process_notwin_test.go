@@ -0,0 +1,51 @@
+//go:build !windows
+
+package runner
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunTimeoutKillsChildProcesses(t *testing.T) {
+	// The grandchild keeps the output pipe open. If it survived, Run would block.
+	result, err := Run(context.Background(), Options{
+		Command: []string{"sh", "-c", "sleep 60 & wait"},
+		Timeout: 100 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.True(t, result.Duration < waitDelay)
+	assert.Equal(t, 128+9, result.ExitCode)
+	assert.False(t, result.Interrupted)
+}
+
+func TestRunForwardsSignals(t *testing.T) {
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	}()
+	result, err := Run(context.Background(), Options{
+		Command: []string{"sh", "-c", "trap 'exit 7' TERM; sleep 10 & wait"},
+		Timeout: 5 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.TimedOut)
+	assert.Equal(t, 7, result.ExitCode)
+	assert.True(t, result.Interrupted)
+}
+
+func TestRunReportsSignaledCommand(t *testing.T) {
+	result, err := Run(context.Background(), Options{
+		Command: []string{"sh", "-c", "kill -TERM $$"},
+		Timeout: 5 * time.Second,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 128+15, result.ExitCode)
+	assert.True(t, result.Interrupted)
+}
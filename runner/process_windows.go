@@ -0,0 +1,26 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// The console delivers Ctrl+C to the command directly.
+// Catching it here keeps this process alive until the command has finished.
+var forwardedSignals = []os.Signal{os.Interrupt}
+
+func setupProcess(cmd *exec.Cmd) {}
+
+// signalProcess is a noop, since Windows can't deliver signals to other processes.
+func signalProcess(cmd *exec.Cmd, sig os.Signal) error {
+	return nil
+}
+
+func exitCode(state *os.ProcessState) int {
+	return state.ExitCode()
+}
+
+// signaled is always false, since Windows has no signals that stop a process.
+func signaled(state *os.ProcessState) bool {
+	return false
+}
@@ -0,0 +1,195 @@
+// Package runner runs an exercise's tests in a child process.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// waitDelay is how long to wait for the output pipes to close after the
+// command has been killed, in case it left processes behind that hold them open.
+const waitDelay = 5 * time.Second
+
+// Options configure a test run.
+type Options struct {
+	// Command is the executable followed by its arguments.
+	Command []string
+
+	// Dir is the directory to run the command in, typically the exercise root.
+	// Defaults to the current directory.
+	Dir string
+
+	// Stdout and Stderr receive the command's output as it is produced.
+	// The output is captured in the Result either way.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// InheritOutput hands this process's own stdout and stderr to the command,
+	// instead of piping them, so that it can detect a terminal and use colors.
+	// Stdout and Stderr are ignored, and the output is not captured.
+	InheritOutput bool
+
+	// Timeout limits how long the command may run. Zero means no limit.
+	Timeout time.Duration
+
+	// Sandbox runs the command without network access, and with a read-only
+	// home directory, except for Dir. It's only supported on Linux, with
+	// unprivileged user namespaces; otherwise Run fails with ErrSandboxUnavailable.
+	Sandbox bool
+}
+
+// Result is the outcome of a command that ran to completion, or was stopped.
+type Result struct {
+	// ExitCode is the exit code of the command.
+	// A command stopped by a signal follows the shell convention of 128 plus the signal number.
+	ExitCode int
+
+	// Duration is how long the command ran for.
+	Duration time.Duration
+
+	// Output is everything the command wrote to stdout and stderr, interleaved.
+	// It is empty if the output was inherited.
+	Output []byte
+
+	// TimedOut is true if the command was killed because it exceeded the timeout.
+	TimedOut bool
+
+	// Interrupted is true if the command was stopped by a signal, or if this process
+	// was interrupted while the command ran, e.g. with Ctrl+C.
+	Interrupted bool
+}
+
+// Passed reports whether the tests passed.
+func (r *Result) Passed() bool {
+	return r.ExitCode == 0 && !r.TimedOut
+}
+
+// Run runs the command and waits for it to finish.
+//
+// A command that fails, times out, or is stopped by a signal still produces a
+// Result; an error means that the command could not be run at all.
+// The command is killed, along with any processes it started, if the
+// context is done or the timeout expires.
+// Interrupt and termination signals sent to this process are forwarded to the
+// command while it runs, so that it can clean up after itself.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	if len(opts.Command) == 0 {
+		return nil, errors.New("no command to run")
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	var sandbox *sandbox
+	if opts.Sandbox {
+		var err error
+		if sandbox, err = newSandbox(opts.Dir); err != nil {
+			return nil, err
+		}
+		defer sandbox.close()
+	}
+
+	output := &lockedBuffer{}
+	cmd := exec.CommandContext(ctx, opts.Command[0], opts.Command[1:]...)
+	cmd.Dir = opts.Dir
+	if opts.InheritOutput {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	} else {
+		cmd.Stdout = teeWriter(output, opts.Stdout)
+		cmd.Stderr = teeWriter(output, opts.Stderr)
+	}
+	cmd.WaitDelay = waitDelay
+	setupProcess(cmd)
+	if sandbox != nil {
+		sandbox.wrap(cmd)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		if sandbox != nil {
+			err = sandbox.startError(err)
+		}
+		return nil, err
+	}
+	if sandbox != nil {
+		if err := sandbox.ready(); err != nil {
+			_ = cmd.Wait()
+			return nil, err
+		}
+	}
+
+	var interrupted atomic.Bool
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				interrupted.Store(true)
+				_ = signalProcess(cmd, sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+	err := cmd.Wait()
+	close(done)
+
+	result := &Result{
+		Duration: time.Since(start),
+		Output:   output.Bytes(),
+		TimedOut: errors.Is(ctx.Err(), context.DeadlineExceeded),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+	case errors.Is(err, exec.ErrWaitDelay):
+		// The command exited but something it started kept the output open.
+	default:
+		return nil, err
+	}
+	result.ExitCode = exitCode(cmd.ProcessState)
+	result.Interrupted = interrupted.Load() || (signaled(cmd.ProcessState) && !result.TimedOut)
+	return result, nil
+}
+
+func teeWriter(buf *lockedBuffer, w io.Writer) io.Writer {
+	if w == nil {
+		return buf
+	}
+	return io.MultiWriter(buf, w)
+}
+
+// lockedBuffer is a buffer that stdout and stderr can be written to concurrently.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
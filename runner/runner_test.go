@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHelperProcess isn't a real test. It's the command that the other tests run.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("EXERCISM_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	switch os.Getenv("EXERCISM_HELPER_MODE") {
+	case "sleep":
+		time.Sleep(time.Minute)
+	case "pwd":
+		wd, _ := os.Getwd()
+		fmt.Print(wd)
+	case "write":
+		for _, path := range filepath.SplitList(os.Getenv("EXERCISM_HELPER_PATHS")) {
+			fmt.Printf("write %s: %v\n", path, os.WriteFile(path, []byte("written"), 0644))
+		}
+	case "network":
+		_, err := net.DialTimeout("tcp", "192.0.2.1:80", time.Second)
+		fmt.Printf("dial: %v\n", err)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		fmt.Printf("listen: %v\n", err)
+		if err == nil {
+			l.Close()
+		}
+	default:
+		fmt.Fprint(os.Stdout, "to stdout\n")
+		fmt.Fprint(os.Stderr, "to stderr\n")
+	}
+	code, _ := strconv.Atoi(os.Getenv("EXERCISM_HELPER_EXIT_CODE"))
+	os.Exit(code)
+}
+
+func helperCommand(t *testing.T, mode string, exitCode int) []string {
+	t.Setenv("EXERCISM_WANT_HELPER_PROCESS", "1")
+	t.Setenv("EXERCISM_HELPER_MODE", mode)
+	t.Setenv("EXERCISM_HELPER_EXIT_CODE", strconv.Itoa(exitCode))
+	return []string{os.Args[0], "-test.run=^TestHelperProcess$"}
+}
+
+func TestRunPassing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	result, err := Run(context.Background(), Options{
+		Command: helperCommand(t, "", 0),
+		Stdout:  &stdout,
+		Stderr:  &stderr,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Passed())
+	assert.Equal(t, 0, result.ExitCode)
+	assert.False(t, result.TimedOut)
+	assert.True(t, result.Duration > 0)
+	assert.Contains(t, string(result.Output), "to stdout")
+	assert.Contains(t, string(result.Output), "to stderr")
+	assert.Equal(t, "to stdout\n", stdout.String())
+	assert.Equal(t, "to stderr\n", stderr.String())
+}
+
+func TestRunFailing(t *testing.T) {
+	result, err := Run(context.Background(), Options{Command: helperCommand(t, "", 3)})
+	assert.NoError(t, err)
+	assert.False(t, result.Passed())
+	assert.Equal(t, 3, result.ExitCode)
+}
+
+func TestRunInDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "runner-dir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	tmpDir, err = filepath.EvalSymlinks(tmpDir)
+	assert.NoError(t, err)
+
+	result, err := Run(context.Background(), Options{Command: helperCommand(t, "pwd", 0), Dir: tmpDir})
+	assert.NoError(t, err)
+	assert.Contains(t, string(result.Output), tmpDir)
+}
+
+func TestRunTimeout(t *testing.T) {
+	result, err := Run(context.Background(), Options{
+		Command: helperCommand(t, "sleep", 0),
+		Timeout: 100 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.TimedOut)
+	assert.False(t, result.Passed())
+	assert.True(t, result.Duration < 30*time.Second)
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result, err := Run(ctx, Options{Command: helperCommand(t, "sleep", 0)})
+	assert.NoError(t, err)
+	assert.False(t, result.Passed())
+}
+
+func TestRunMissingExecutable(t *testing.T) {
+	_, err := Run(context.Background(), Options{Command: []string{"exercism-bogus-executable-that-does-not-exist"}})
+	assert.Error(t, err)
+
+	_, err = Run(context.Background(), Options{})
+	assert.Error(t, err)
+}
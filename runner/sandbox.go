@@ -0,0 +1,7 @@
+package runner
+
+import "errors"
+
+// ErrSandboxUnavailable means that the command can't be isolated on this system,
+// so it was not run at all.
+var ErrSandboxUnavailable = errors.New("sandbox unavailable")
@@ -0,0 +1,291 @@
+package runner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// sandboxInitArg is the name the sandbox runs this executable under,
+// to set up the isolated environment before it runs the command.
+const sandboxInitArg = "exercism-sandbox-init"
+
+// sandboxErrorsFd is where the sandbox setup reports what went wrong.
+// It's closed when the command starts.
+const sandboxErrorsFd = 3
+
+// Capabilities, and the prctl options to drop them again.
+// See capability.h and prctl.h.
+const (
+	capSetPCap  = 8
+	capNetAdmin = 12
+	capSysAdmin = 21
+
+	prSetSecurebits       = 28
+	prCapAmbient          = 47
+	prCapAmbientClearAll  = 4
+	secbitNoRoot          = 1 << 0
+	secbitNoRootLocked    = 1 << 1
+	secbitNoCapAmbRaise   = 1 << 6
+	secbitNoCapAmbRLocked = 1 << 7
+)
+
+func init() {
+	if len(os.Args) == 0 || os.Args[0] != sandboxInitArg {
+		return
+	}
+	// Only returns if the command couldn't be started.
+	err := sandboxInit(os.Args[1:])
+	errs := os.NewFile(sandboxErrorsFd, "sandbox-errors")
+	fmt.Fprint(errs, err)
+	os.Exit(127)
+}
+
+// sandbox runs the command in new user, network and mount namespaces.
+// The network is disabled apart from the loopback interface,
+// and the home directory is read-only, except for the exercise directory.
+type sandbox struct {
+	home   string
+	dir    string
+	errors *os.File
+	report *os.File
+}
+
+func newSandbox(dir string) (*sandbox, error) {
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Without a home directory there's nothing to protect there.
+	home, _ := os.UserHomeDir()
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	return &sandbox{home: home, dir: dir, errors: r, report: w}, nil
+}
+
+// wrap makes the command start in the sandbox.
+// This executable runs first, as sandboxInitArg, and execs the command once the sandbox is set up.
+func (s *sandbox) wrap(cmd *exec.Cmd) {
+	args := append([]string{sandboxInitArg, s.home, s.dir, cmd.Path}, cmd.Args...)
+	cmd.Path = "/proc/self/exe"
+	cmd.Args = args
+	cmd.ExtraFiles = []*os.File{s.report}
+
+	attr := cmd.SysProcAttr
+	attr.Cloneflags = syscall.CLONE_NEWUSER | syscall.CLONE_NEWNET | syscall.CLONE_NEWNS
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1}}
+	// Enough to set up the sandbox. They are dropped before the command runs.
+	attr.AmbientCaps = []uintptr{capSetPCap, capNetAdmin, capSysAdmin}
+}
+
+// startError explains why the sandbox couldn't be created.
+func (s *sandbox) startError(err error) error {
+	for _, errno := range []syscall.Errno{syscall.EPERM, syscall.EACCES, syscall.EINVAL, syscall.ENOSPC, syscall.EUSERS} {
+		if errors.Is(err, errno) {
+			return fmt.Errorf("%w: can't create user namespaces: %s", ErrSandboxUnavailable, err)
+		}
+	}
+	return err
+}
+
+// ready waits until the command has started in the sandbox, or the setup failed.
+func (s *sandbox) ready() error {
+	s.report.Close()
+	msg, err := io.ReadAll(s.errors)
+	if err != nil {
+		return err
+	}
+	if len(msg) > 0 {
+		return fmt.Errorf("%w: %s", ErrSandboxUnavailable, msg)
+	}
+	return nil
+}
+
+func (s *sandbox) close() {
+	s.report.Close()
+	s.errors.Close()
+}
+
+// sandboxInit runs inside the new namespaces. It sets up the mounts and the network,
+// drops the capabilities needed to do so, and replaces itself with the command.
+func sandboxInit(args []string) error {
+	if len(args) < 4 {
+		return errors.New("invalid sandbox arguments")
+	}
+	home, dir, path, argv := args[0], args[1], args[2], args[3:]
+
+	// Capabilities and securebits are per thread; they need to apply to the exec.
+	runtime.LockOSThread()
+
+	// Keep the mounts below from propagating back to the rest of the system.
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("can't make mounts private: %w", err)
+	}
+	protectHome := home != "" && !isWithin(home, dir)
+	if protectHome {
+		if err := syscall.Mount(home, home, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+			return fmt.Errorf("can't bind mount %s: %w", home, err)
+		}
+	}
+	// A mount of its own keeps the exercise directory writable.
+	if err := syscall.Mount(dir, dir, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("can't bind mount %s: %w", dir, err)
+	}
+	if protectHome {
+		if err := protectMounts(home, dir); err != nil {
+			return err
+		}
+	}
+	if err := bringUpLoopback(); err != nil {
+		return fmt.Errorf("can't set up the loopback interface: %w", err)
+	}
+
+	if err := prctl(prSetSecurebits, secbitNoRoot|secbitNoRootLocked|secbitNoCapAmbRaise|secbitNoCapAmbRLocked, 0); err != nil {
+		return fmt.Errorf("can't drop capabilities: %w", err)
+	}
+	if err := prctl(prCapAmbient, prCapAmbientClearAll, 0); err != nil {
+		return fmt.Errorf("can't drop capabilities: %w", err)
+	}
+	syscall.CloseOnExec(sandboxErrorsFd)
+	return syscall.Exec(path, argv, os.Environ())
+}
+
+// isWithin reports whether path is dir or one of its subdirectories.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// protectMounts makes the home directory read-only, along with everything mounted within it,
+// such as a separate file system for a workspace. The exercise directory, and whatever is mounted
+// within it, stays writable.
+func protectMounts(home, dir string) error {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	mountPoints, err := mountPointsWithin(f, home)
+	if err != nil {
+		return fmt.Errorf("can't read the mounts: %w", err)
+	}
+	for _, mountPoint := range mountPoints {
+		if isWithin(mountPoint, dir) {
+			continue
+		}
+		if err := remountReadOnly(mountPoint); err != nil {
+			return fmt.Errorf("can't make %s read-only: %w", mountPoint, err)
+		}
+	}
+	return nil
+}
+
+// mountPointsWithin lists the mount points in the mountinfo that are dir or within it, once each,
+// in the order they were mounted. See proc(5) for the format.
+func mountPointsWithin(mountinfo io.Reader, dir string) ([]string, error) {
+	var mountPoints []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("invalid mountinfo line %q", scanner.Text())
+		}
+		mountPoint := unescapeMountPath(fields[4])
+		if seen[mountPoint] || !isWithin(mountPoint, dir) {
+			continue
+		}
+		seen[mountPoint] = true
+		mountPoints = append(mountPoints, mountPoint)
+	}
+	return mountPoints, scanner.Err()
+}
+
+// unescapeMountPath undoes the octal escapes of mountinfo, e.g. \040 for a space.
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if n, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// remountReadOnly makes a bind mount read-only.
+// The flags that are already set must be kept, since a user namespace is not allowed to clear them.
+func remountReadOnly(path string) error {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		0x2:    syscall.MS_NOSUID,
+		0x4:    syscall.MS_NODEV,
+		0x8:    syscall.MS_NOEXEC,
+		0x400:  syscall.MS_NOATIME,
+		0x800:  syscall.MS_NODIRATIME,
+		0x1000: syscall.MS_RELATIME,
+	} {
+		if int64(st.Flags)&stFlag != 0 {
+			flags |= msFlag
+		}
+	}
+	return syscall.Mount("", path, "", flags, "")
+}
+
+// bringUpLoopback enables the loopback interface of the new network namespace,
+// so that tests can still talk to servers they start themselves.
+func bringUpLoopback() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	// struct ifreq, with ifr_flags
+	var req struct {
+		name  [syscall.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(req.name[:], "lo")
+	req.flags = syscall.IFF_UP | syscall.IFF_LOOPBACK | syscall.IFF_RUNNING
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&req)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func prctl(option, arg2, arg3 uintptr) error {
+	_, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, option, arg2, arg3)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
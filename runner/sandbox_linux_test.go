@@ -0,0 +1,75 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runSandboxed(t *testing.T, opts Options) *Result {
+	opts.Sandbox = true
+	result, err := Run(context.Background(), opts)
+	if errors.Is(err, ErrSandboxUnavailable) {
+		t.Skipf("can't test the sandbox: %s", err)
+	}
+	assert.NoError(t, err)
+	return result
+}
+
+func TestSandboxMakesHomeReadOnly(t *testing.T) {
+	home, err := os.MkdirTemp("", "sandbox-home")
+	assert.NoError(t, err)
+	defer os.RemoveAll(home)
+	dir := filepath.Join(home, "track", "exercise")
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	assert.NoError(t, err)
+	t.Setenv("HOME", home)
+
+	inHome := filepath.Join(home, "in-home.txt")
+	inExercise := filepath.Join(dir, "in-exercise.txt")
+	t.Setenv("EXERCISM_HELPER_PATHS", inHome+string(os.PathListSeparator)+inExercise)
+	result := runSandboxed(t, Options{Command: helperCommand(t, "write", 0), Dir: dir})
+
+	assert.Contains(t, string(result.Output), "write "+inHome+": open "+inHome+": read-only file system")
+	assert.Contains(t, string(result.Output), "write "+inExercise+": <nil>")
+	assert.NoFileExists(t, inHome)
+	assert.FileExists(t, inExercise)
+}
+
+func TestSandboxDisablesNetwork(t *testing.T) {
+	result := runSandboxed(t, Options{Command: helperCommand(t, "network", 0)})
+
+	assert.Regexp(t, "dial: .*network is unreachable", string(result.Output))
+	assert.Contains(t, string(result.Output), "listen: <nil>")
+}
+
+func TestSandboxReportsMissingCommand(t *testing.T) {
+	_, err := Run(context.Background(), Options{Command: []string{"no-such-command-for-the-sandbox"}, Sandbox: true})
+	assert.Error(t, err)
+}
+
+func TestMountPointsWithin(t *testing.T) {
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+23 22 8:2 / /home rw,relatime shared:2 - ext4 /dev/sda2 rw
+24 23 8:3 / /home/user/Exercism rw,relatime shared:3 - ext4 /dev/sda3 rw
+25 23 0:40 / /home/user/My\040Drive rw,nosuid shared:4 - fuse.rclone remote: rw
+26 24 0:41 / /home/user/Exercism/go/leap/cache rw shared:5 - tmpfs tmpfs rw
+27 22 0:42 / /home/username rw shared:6 - tmpfs tmpfs rw
+28 23 8:2 / /home/user/Exercism rw,relatime shared:7 - ext4 /dev/sda2 rw
+`
+	mountPoints, err := mountPointsWithin(strings.NewReader(mountinfo), "/home/user")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/home/user/Exercism",
+		"/home/user/My Drive",
+		"/home/user/Exercism/go/leap/cache",
+	}, mountPoints)
+
+	_, err = mountPointsWithin(strings.NewReader("garbage\n"), "/home/user")
+	assert.Error(t, err)
+}
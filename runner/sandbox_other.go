@@ -0,0 +1,27 @@
+//go:build !linux
+
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// sandbox is only implemented on Linux.
+type sandbox struct{}
+
+func newSandbox(dir string) (*sandbox, error) {
+	return nil, fmt.Errorf("%w: sandboxing is only supported on Linux", ErrSandboxUnavailable)
+}
+
+func (s *sandbox) wrap(cmd *exec.Cmd) {}
+
+func (s *sandbox) startError(err error) error {
+	return err
+}
+
+func (s *sandbox) ready() error {
+	return nil
+}
+
+func (s *sandbox) close() {}
@@ -1,9 +1,25 @@
+# Cd
+complete -f -c exercism -n "__fish_use_subcommand" -a "cd" -d "Outputs the path to an exercise in the workspace."
+complete -f -c exercism -n "__fish_seen_subcommand_from cd" -s h -l help -d "help for cd"
+
 # Configure
 complete -f -c exercism -n "__fish_use_subcommand" -a "configure" -d "Writes config values to a JSON file."
 complete -f -c exercism -n "__fish_seen_subcommand_from configure" -s t -l token -d "Set token"
 complete -f -c exercism -n "__fish_seen_subcommand_from configure" -s w -l workspace -d "Set workspace"
 complete -f -c exercism -n "__fish_seen_subcommand_from configure" -s a -l api -d "set API base url"
 complete -f -c exercism -n "__fish_seen_subcommand_from configure" -s s -l show -d "show settings"
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -s i -l interactive -d "set up by answering questions"
+complete -c exercism -n "__fish_seen_subcommand_from configure" -l credential-helper -d "program that keeps the token"
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -l autocommit -a "workspace track off" -d "commit exercises to git"
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -a "get" -d "Print the value of a configuration key."
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -a "set" -d "Set the value of a configuration key."
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -a "unset" -d "Remove a configuration key."
+complete -f -c exercism -n "__fish_seen_subcommand_from configure" -a "list" -d "List the configuration keys and where their values came from."
+
+# Doctor
+complete -f -c exercism -n "__fish_use_subcommand" -a "doctor" -d "Find and repair problems in your workspace."
+complete -f -c exercism -n "__fish_seen_subcommand_from doctor" -l fix -d "apply the repairs that are safe to make automatically"
+complete -f -c exercism -n "__fish_seen_subcommand_from doctor" -s h -l help -d "help for doctor"
 
 # Download
 complete -f -c exercism -n "__fish_use_subcommand" -a "download" -d "Downloads and saves a specified submission into the local system"
@@ -14,12 +30,30 @@ complete -f -c exercism -n "__fish_seen_subcommand_from download" -s u -l uuid -
 
 # Help
 complete -f -c exercism -n "__fish_use_subcommand" -a "help" -d "Shows a list of commands or help for one command"
-complete -f -c exercism -n "__fish_seen_subcommand_from help" -a "configure download help open submit test troubleshoot upgrade version workspace"
+complete -f -c exercism -n "__fish_seen_subcommand_from help" -a "cd configure doctor download help list open profiles status submit test troubleshoot upgrade version workspace"
+
+# List
+complete -f -c exercism -n "__fish_use_subcommand" -a "list" -d "List the exercises in your workspace."
+complete -f -c exercism -n "__fish_seen_subcommand_from list" -s t -l track -d "only list the exercises of this track"
+complete -f -c exercism -n "__fish_seen_subcommand_from list" -s a -l author -d "only list the exercises by the person with this handle"
+complete -f -c exercism -n "__fish_seen_subcommand_from list" -s s -l sort -a "track exercise author submitted" -d "sort by track, exercise, author or submitted"
+complete -f -c exercism -n "__fish_seen_subcommand_from list" -l json -d "output the list as JSON"
+complete -f -c exercism -n "__fish_seen_subcommand_from list" -s h -l help -d "help for list"
 
 # Open
 complete -f -c exercism -n "__fish_use_subcommand" -a "open" -d "Opens a browser to exercism.org for the specified submission."
 complete -f -c exercism -n "__fish_seen_subcommand_from open" -s h -l help -d "help for open"
 
+# Profiles
+complete -f -c exercism -n "__fish_use_subcommand" -a "profiles" -d "List your configuration profiles."
+complete -f -c exercism -n "__fish_seen_subcommand_from profiles" -s h -l help -d "help for profiles"
+
+# Status
+complete -f -c exercism -n "__fish_use_subcommand" -a "status" -d "Show which exercises have unsubmitted changes."
+complete -f -c exercism -n "__fish_seen_subcommand_from status" -s t -l track -d "only show the exercises of this track"
+complete -f -c exercism -n "__fish_seen_subcommand_from status" -l json -d "output the status as JSON"
+complete -f -c exercism -n "__fish_seen_subcommand_from status" -s h -l help -d "help for status"
+
 # Submit
 complete -f -c exercism -n "__fish_use_subcommand" -a "submit" -d "Submits a new iteration to a problem on exercism.org."
 complete -f -c exercism -n "__fish_seen_subcommand_from submit" -s h -l help -d "help for submit"
@@ -55,3 +89,7 @@ complete -f -c exercism -l timeout -a "300" -d "5 minutes"
 complete -f -c exercism -l timeout -a "600" -d "10 minutes"
 complete -f -c exercism -l timeout -a "" -d "override default HTTP timeout"
 complete -f -c exercism -s v -l verbose -d "turn on verbose logging"
+complete -f -c exercism -l profile -d "the configuration profile to use"
+complete -c exercism -l config -d "the user config file to use"
+complete -c exercism -l workspace -d "the workspace to use"
+complete -f -c exercism -l api -d "the API base URL to use"
@@ -4,11 +4,11 @@ _exercism () {
   COMPREPLY=()   # Array variable storing the possible completions.
   cur=${COMP_WORDS[COMP_CWORD]}
   prev=${COMP_WORDS[COMP_CWORD-1]}
-  opts="--verbose --timeout"
+  opts="--verbose --timeout --profile --config --workspace --api"
 
-  commands="configure download open
-  submit test troubleshoot upgrade version workspace help"
-  config_opts="--show"
+  commands="cd configure doctor download list open
+  profiles status submit test troubleshoot upgrade version workspace help"
+  config_opts="--show --interactive get set unset list"
   version_opts="--latest"
 
   if [ "${#COMP_WORDS[@]}" -eq 2 ]; then
@@ -0,0 +1,19 @@
+// Package shell holds the shell completion scripts, so that the CLI can install them.
+package shell
+
+import _ "embed"
+
+// Bash is the completion script for bash.
+//
+//go:embed exercism_completion.bash
+var Bash string
+
+// Zsh is the completion script for zsh.
+//
+//go:embed exercism_completion.zsh
+var Zsh string
+
+// Fish is the completion script for fish.
+//
+//go:embed exercism.fish
+var Fish string
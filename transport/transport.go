@@ -0,0 +1,141 @@
+// Package transport builds the HTTP transport that all of the CLI's HTTP calls share,
+// so that they go through the same proxy, trust the same certificate authorities,
+// and present the same client certificate.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TLSVersions are the allowed values of the minimum TLS version.
+var TLSVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Settings configure the transport. The zero value is the same as Go's default transport.
+type Settings struct {
+	// Proxy is the URL of the proxy for all requests.
+	// If it's empty, the HTTPS_PROXY and HTTP_PROXY environment variables apply.
+	Proxy string
+	// NoProxy lists the hosts that are reached without the proxy, in the format of NO_PROXY.
+	// If it's empty, the NO_PROXY environment variable applies.
+	NoProxy string
+	// CAFile is a PEM bundle of certificate authorities to trust on top of the system's,
+	// e.g. for a proxy that intercepts TLS.
+	CAFile string
+	// ClientCert and ClientKey are PEM files of a certificate to present to servers that ask for one.
+	ClientCert string
+	ClientKey  string
+	// MinTLSVersion is the oldest TLS version to accept, see TLSVersions.
+	MinTLSVersion string
+}
+
+// FromConfig reads the settings from the user config.
+func FromConfig(v *viper.Viper) Settings {
+	return Settings{
+		Proxy:         v.GetString("proxy"),
+		NoProxy:       v.GetString("noproxy"),
+		CAFile:        v.GetString("cafile"),
+		ClientCert:    v.GetString("clientcert"),
+		ClientKey:     v.GetString("clientkey"),
+		MinTLSVersion: v.GetString("mintlsversion"),
+	}
+}
+
+// ProxyConfig combines the proxy settings with the environment.
+func (s Settings) ProxyConfig() *httpproxy.Config {
+	cfg := httpproxy.FromEnvironment()
+	if s.Proxy != "" {
+		cfg.HTTPProxy = s.Proxy
+		cfg.HTTPSProxy = s.Proxy
+	}
+	if s.NoProxy != "" {
+		cfg.NoProxy = s.NoProxy
+	}
+	return cfg
+}
+
+// ProxyFor describes the proxy that a request to the URL goes through, or "none".
+func (s Settings) ProxyFor(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	proxy, err := s.ProxyConfig().ProxyFunc()(u)
+	if err != nil {
+		return "", err
+	}
+	if proxy == nil {
+		return "none", nil
+	}
+	return proxy.Redacted(), nil
+}
+
+// New builds a transport with the settings.
+func (s Settings) New() (*http.Transport, error) {
+	if s.Proxy != "" {
+		if u, err := url.Parse(s.Proxy); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", s.Proxy)
+		}
+	}
+
+	tlsConfig := &tls.Config{}
+	if s.MinTLSVersion != "" {
+		version, ok := TLSVersions[s.MinTLSVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid minimum TLS version %q, use 1.2 or 1.3", s.MinTLSVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+	if s.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		b, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no PEM certificates in the CA file %s", s.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if s.ClientCert != "" || s.ClientKey != "" {
+		if s.ClientCert == "" || s.ClientKey == "" {
+			return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+		}
+		cert, err := tls.LoadX509KeyPair(s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	proxyFunc := s.ProxyConfig().ProxyFunc()
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	t.TLSClientConfig = tlsConfig
+	return t, nil
+}
+
+// NewClient builds an HTTP client on a transport with the settings.
+func (s Settings) NewClient(timeoutInSeconds int) (*http.Client, error) {
+	t, err := s.New()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: t, Timeout: time.Duration(timeoutInSeconds) * time.Second}, nil
+}
@@ -0,0 +1,305 @@
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+)
+
+// ProblemKind is the kind of problem found in a workspace.
+type ProblemKind int
+
+// ProblemKind
+const (
+	ProblemLegacyMetadata ProblemKind = iota
+	ProblemNumericSuffix
+	ProblemSlugMismatch
+	ProblemMissingConfig
+	ProblemPermissions
+	ProblemOrphanedUserDir
+	ProblemInvalidMetadata
+)
+
+func (k ProblemKind) String() string {
+	switch k {
+	case ProblemLegacyMetadata:
+		return "legacy metadata"
+	case ProblemNumericSuffix:
+		return "numeric suffix"
+	case ProblemSlugMismatch:
+		return "slug mismatch"
+	case ProblemMissingConfig:
+		return "missing config"
+	case ProblemPermissions:
+		return "permissions"
+	case ProblemInvalidMetadata:
+		return "invalid metadata"
+	default:
+		return "orphaned user directory"
+	}
+}
+
+// Problem is something wrong in the workspace that gets in the way of the CLI.
+type Problem struct {
+	Kind ProblemKind
+	// Path is the file or directory with the problem.
+	Path        string
+	Description string
+	// Repair describes how Fix repairs the problem.
+	// It is empty if the problem can't be repaired automatically.
+	Repair string
+	fix    func() error
+}
+
+// Fixable reports whether the problem can be repaired automatically.
+func (p Problem) Fixable() bool {
+	return p.fix != nil
+}
+
+// Fix repairs the problem.
+func (p Problem) Fix() error {
+	if p.fix == nil {
+		return fmt.Errorf("%s can't be repaired automatically", p.Kind)
+	}
+	return p.fix()
+}
+
+var rgxNumericSuffix = regexp.MustCompile(`\A(.+)-\d+\z`)
+
+// Diagnose scans the workspace for problems: legacy metadata files, exercise directories
+// that don't match their slug, missing exercise config, files that the user can't read or write,
+// and directories in users/ that don't hold any exercises.
+//
+// The problems of an exercise are listed in the order in which they should be fixed.
+// Renaming the exercise directory comes last, since it changes the paths of the other problems.
+func (ws Workspace) Diagnose() ([]Problem, error) {
+	candidates, err := ws.PotentialExercises()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []Problem{}
+	usersDir := filepath.Join(ws.Dir, "users")
+	handleInfos, err := os.ReadDir(usersDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, handleInfo := range handleInfos {
+		if !handleInfo.IsDir() {
+			continue
+		}
+		userDir := filepath.Join(usersDir, handleInfo.Name())
+		userCandidates, err := Workspace{Dir: userDir}.PotentialExercises()
+		if err != nil {
+			return nil, err
+		}
+		found, err := hasExercises(userCandidates)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			problems = append(problems, orphanedUserDirProblem(userDir))
+			continue
+		}
+		candidates = append(candidates, userCandidates...)
+	}
+
+	for _, exercise := range candidates {
+		exerciseProblems, err := diagnoseExercise(exercise)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, exerciseProblems...)
+	}
+	return problems, nil
+}
+
+func hasExercises(candidates []Exercise) (bool, error) {
+	for _, candidate := range candidates {
+		for _, check := range []func() (bool, error){candidate.HasMetadata, candidate.HasLegacyMetadata} {
+			ok, err := check()
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func diagnoseExercise(exercise Exercise) ([]Problem, error) {
+	problems := []Problem{}
+
+	hasLegacy, err := exercise.HasLegacyMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if hasLegacy {
+		problems = append(problems, Problem{
+			Kind:        ProblemLegacyMetadata,
+			Path:        exercise.LegacyMetadataFilepath(),
+			Description: "the exercise has a metadata file from an old version of the CLI",
+			Repair:      fmt.Sprintf("move it to %s", metadataFilepath),
+			fix: func() error {
+				_, err := exercise.MigrateLegacyMetadataFile()
+				return err
+			},
+		})
+	}
+
+	hasMetadata, err := exercise.HasMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if !hasMetadata && !hasLegacy {
+		// Not an exercise, or one that Exercism doesn't know about.
+		return problems, nil
+	}
+
+	permissionProblems, err := diagnosePermissions(exercise.Filepath())
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, permissionProblems...)
+
+	configPath := filepath.Join(exercise.Filepath(), configFilepath)
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		problems = append(problems, Problem{
+			Kind:        ProblemMissingConfig,
+			Path:        configPath,
+			Description: "the exercise config is missing, so the CLI doesn't know which files to submit or test",
+		})
+	}
+
+	if !hasMetadata {
+		// The slug is only known once the legacy metadata has been migrated.
+		return problems, nil
+	}
+	metadata, err := NewExerciseMetadata(exercise.Filepath())
+	if err != nil {
+		return append(problems, Problem{
+			Kind:        ProblemInvalidMetadata,
+			Path:        exercise.MetadataFilepath(),
+			Description: fmt.Sprintf("the exercise metadata can't be read: %s", err),
+		}), nil
+	}
+	if metadata.ExerciseSlug == "" || metadata.ExerciseSlug == exercise.Slug {
+		return problems, nil
+	}
+
+	kind := ProblemSlugMismatch
+	description := fmt.Sprintf("the directory name doesn't match the exercise slug %q in the metadata", metadata.ExerciseSlug)
+	if match := rgxNumericSuffix.FindStringSubmatch(exercise.Slug); match != nil && match[1] == metadata.ExerciseSlug {
+		kind = ProblemNumericSuffix
+		description = "the directory has a numeric suffix, which is no longer supported"
+	}
+	target := filepath.Join(filepath.Dir(exercise.Filepath()), metadata.ExerciseSlug)
+	problem := Problem{
+		Kind:        kind,
+		Path:        exercise.Filepath(),
+		Description: description,
+	}
+	if _, err := os.Lstat(target); os.IsNotExist(err) {
+		problem.Repair = fmt.Sprintf("rename the directory to %s", target)
+		problem.fix = func() error {
+			return os.Rename(exercise.Filepath(), target)
+		}
+	} else {
+		problem.Description += fmt.Sprintf(", and %s already exists", target)
+	}
+	return append(problems, problem), nil
+}
+
+// diagnosePermissions finds files in the exercise directory that the user can't write,
+// and directories that the user can't list or add files to.
+func diagnosePermissions(dir string) ([]Problem, error) {
+	// Windows only has a read-only attribute, which the CLI never sets.
+	if runtime.GOOS == "windows" {
+		return nil, nil
+	}
+
+	problems := []Problem{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d == nil || !os.IsPermission(err) {
+				return err
+			}
+			// Most likely it belongs to someone else, e.g. root.
+			problems = append(problems, Problem{
+				Kind:        ProblemPermissions,
+				Path:        path,
+				Description: "the directory can't be read",
+			})
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		want := os.FileMode(0600)
+		if info.IsDir() {
+			want = 0700
+		}
+		mode := info.Mode().Perm()
+		if mode&want == want {
+			return nil
+		}
+		problems = append(problems, Problem{
+			Kind:        ProblemPermissions,
+			Path:        path,
+			Description: fmt.Sprintf("the permissions are %s, so the CLI can't update it", mode),
+			Repair:      fmt.Sprintf("change the permissions to %s", mode|want),
+			fix: func() error {
+				return os.Chmod(path, mode|want)
+			},
+		})
+		if info.IsDir() && mode&0500 != 0500 {
+			// Its contents can't be checked until it's fixed.
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+func orphanedUserDirProblem(dir string) Problem {
+	problem := Problem{
+		Kind:        ProblemOrphanedUserDir,
+		Path:        dir,
+		Description: "the directory doesn't hold any exercises",
+	}
+	if empty, err := onlyDirectories(dir); err == nil && empty {
+		problem.Repair = "remove the empty directory"
+		problem.fix = func() error {
+			return os.RemoveAll(dir)
+		}
+	}
+	return problem
+}
+
+// onlyDirectories reports whether dir holds nothing but (possibly nested) empty directories.
+func onlyDirectories(dir string) (bool, error) {
+	empty := true
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			empty = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return empty, err
+}
@@ -0,0 +1,123 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeDoctorExercise(t *testing.T, dir, slug string, withConfig bool) {
+	t.Helper()
+	writeTestExercise(t, dir, ExerciseMetadata{Track: "bogus-track", ExerciseSlug: slug, IsRequester: true})
+	if withConfig {
+		err := os.WriteFile(filepath.Join(dir, configFilepath), []byte(`{"files": {"solution": ["solution.txt"]}}`), os.FileMode(0644))
+		assert.NoError(t, err)
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	healthy := filepath.Join(tmpDir, "bogus-track", "healthy")
+	writeDoctorExercise(t, healthy, "healthy", true)
+
+	legacy := filepath.Join(tmpDir, "bogus-track", "legacy")
+	err := os.MkdirAll(filepath.Join(legacy, ignoreSubdir), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(legacy, configFilepath), []byte(`{}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(legacy, legacyMetadataFilename), []byte(`{"track": "bogus-track", "exercise": "legacy"}`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	suffixed := filepath.Join(tmpDir, "bogus-track", "suffixed-2")
+	writeDoctorExercise(t, suffixed, "suffixed", true)
+
+	mismatch := filepath.Join(tmpDir, "bogus-track", "wrong-name")
+	writeDoctorExercise(t, mismatch, "right-name", true)
+
+	noConfig := filepath.Join(tmpDir, "bogus-track", "no-config")
+	writeDoctorExercise(t, noConfig, "no-config", false)
+
+	orphan := filepath.Join(tmpDir, "users", "alice", "bogus-track")
+	err = os.MkdirAll(orphan, os.FileMode(0755))
+	assert.NoError(t, err)
+
+	ws, err := New(tmpDir)
+	assert.NoError(t, err)
+	problems, err := ws.Diagnose()
+	assert.NoError(t, err)
+
+	found := map[ProblemKind]string{}
+	for _, problem := range problems {
+		found[problem.Kind] = problem.Path
+	}
+	assert.Equal(t, filepath.Join(ws.Dir, "bogus-track", "legacy", legacyMetadataFilename), found[ProblemLegacyMetadata])
+	assert.Equal(t, filepath.Join(ws.Dir, "bogus-track", "suffixed-2"), found[ProblemNumericSuffix])
+	assert.Equal(t, filepath.Join(ws.Dir, "bogus-track", "wrong-name"), found[ProblemSlugMismatch])
+	assert.Equal(t, filepath.Join(ws.Dir, "bogus-track", "no-config", configFilepath), found[ProblemMissingConfig])
+	assert.Equal(t, filepath.Join(ws.Dir, "users", "alice"), found[ProblemOrphanedUserDir])
+	assert.Len(t, problems, 5)
+
+	for _, problem := range problems {
+		if problem.Fixable() {
+			assert.NoError(t, problem.Fix())
+		}
+	}
+
+	problems, err = ws.Diagnose()
+	assert.NoError(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, ProblemMissingConfig, problems[0].Kind)
+		assert.False(t, problems[0].Fixable())
+	}
+	assert.DirExists(t, filepath.Join(tmpDir, "bogus-track", "suffixed"))
+	assert.DirExists(t, filepath.Join(tmpDir, "bogus-track", "right-name"))
+	assert.FileExists(t, filepath.Join(legacy, metadataFilepath))
+	assert.NoDirExists(t, filepath.Join(tmpDir, "users", "alice"))
+}
+
+func TestDiagnoseRenameConflict(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeDoctorExercise(t, filepath.Join(tmpDir, "bogus-track", "two-fer"), "two-fer", true)
+	writeDoctorExercise(t, filepath.Join(tmpDir, "bogus-track", "two-fer-1"), "two-fer", true)
+
+	ws, err := New(tmpDir)
+	assert.NoError(t, err)
+	problems, err := ws.Diagnose()
+	assert.NoError(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, ProblemNumericSuffix, problems[0].Kind)
+		assert.False(t, problems[0].Fixable())
+		assert.Contains(t, problems[0].Description, "already exists")
+	}
+}
+
+func TestDiagnosePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissions aren't checked on Windows")
+	}
+	tmpDir := t.TempDir()
+
+	dir := filepath.Join(tmpDir, "bogus-track", "read-only")
+	writeDoctorExercise(t, dir, "read-only", true)
+	file := filepath.Join(dir, "solution.txt")
+	err := os.Chmod(file, os.FileMode(0444))
+	assert.NoError(t, err)
+
+	ws, err := New(tmpDir)
+	assert.NoError(t, err)
+	problems, err := ws.Diagnose()
+	assert.NoError(t, err)
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, ProblemPermissions, problems[0].Kind)
+		assert.NoError(t, problems[0].Fix())
+	}
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
@@ -17,7 +17,9 @@ type ExerciseConfig struct {
 	Files struct {
 		Solution []string `json:"solution"`
 		Test     []string `json:"test"`
+		Editor   []string `json:"editor"`
 	} `json:"files"`
+	TestCommand string `json:"test_command,omitempty"`
 }
 
 // NewExerciseConfig reads exercise metadata from a file in the given directory.
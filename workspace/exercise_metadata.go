@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/exercism/cli/atomicfile"
 )
 
 const metadataFilename = "metadata.json"
@@ -24,8 +26,16 @@ type ExerciseMetadata struct {
 	Handle       string     `json:"handle"`
 	IsRequester  bool       `json:"is_requester"`
 	SubmittedAt  *time.Time `json:"submitted_at,omitempty"`
-	Dir          string     `json:"-"`
-	AutoApprove  bool       `json:"auto_approve"`
+	// DownloadedAt is when the exercise was last downloaded to this machine.
+	// It is empty for exercises downloaded by older versions of the CLI.
+	DownloadedAt *time.Time `json:"downloaded_at,omitempty"`
+	// Iteration counts the submissions made from this machine.
+	Iteration   int    `json:"iteration,omitempty"`
+	Dir         string `json:"-"`
+	AutoApprove bool   `json:"auto_approve"`
+	// Checksums of the exercise's files as they were last submitted or downloaded,
+	// by path relative to the exercise directory.
+	Checksums map[string]string `json:"checksums,omitempty"`
 }
 
 // NewExerciseMetadata reads exercise metadata from a file in the given directory.
@@ -70,13 +80,36 @@ func (em *ExerciseMetadata) Write(dir string) error {
 	if err = os.MkdirAll(filepath.Dir(metadataAbsoluteFilepath), os.FileMode(0755)); err != nil {
 		return err
 	}
-	if err = os.WriteFile(metadataAbsoluteFilepath, b, os.FileMode(0600)); err != nil {
+	if err = atomicfile.WriteFile(metadataAbsoluteFilepath, b, os.FileMode(0600)); err != nil {
 		return err
 	}
 	em.Dir = dir
 	return nil
 }
 
+// UpdateExerciseMetadata reads the exercise metadata in the given directory, changes it with fn,
+// and writes it back. The metadata file is locked meanwhile, so that concurrent updates
+// from other processes aren't lost.
+func UpdateExerciseMetadata(dir string, fn func(*ExerciseMetadata) error) (*ExerciseMetadata, error) {
+	unlock, err := atomicfile.Lock(filepath.Join(dir, metadataFilepath))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	metadata, err := NewExerciseMetadata(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(metadata); err != nil {
+		return nil, err
+	}
+	if err := metadata.Write(dir); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
 // PathToParent is the relative path from the workspace to the parent dir.
 func (em *ExerciseMetadata) PathToParent() string {
 	var dir string
@@ -2,6 +2,8 @@ package workspace
 
 import (
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -41,6 +43,42 @@ func TestExerciseMetadata(t *testing.T) {
 	assert.Equal(t, em2, em3)
 }
 
+func TestUpdateExerciseMetadataWithParallelWriters(t *testing.T) {
+	dir, err := os.MkdirTemp("", "solution")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	em := &ExerciseMetadata{Track: "a-track", ExerciseSlug: "bogus-exercise", ID: "abc"}
+	err = em.Write(dir)
+	assert.NoError(t, err)
+
+	const writers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := UpdateExerciseMetadata(dir, func(em *ExerciseMetadata) error {
+				em.Iteration++
+				return nil
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	em, err = NewExerciseMetadata(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, writers, em.Iteration)
+	assert.Equal(t, "abc", em.ID)
+	assert.NoFileExists(t, filepath.Join(dir, metadataFilepath+".lock"))
+}
+
 func TestSuffix(t *testing.T) {
 	testCases := []struct {
 		metadata ExerciseMetadata
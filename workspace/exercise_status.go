@@ -0,0 +1,125 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ExerciseStatus describes how an exercise's files compare to the last submitted or downloaded state.
+type ExerciseStatus int
+
+const (
+	// ExerciseStatusUnknown means that no checksums were recorded for the exercise,
+	// e.g. because it was downloaded by an older version of the CLI.
+	ExerciseStatusUnknown ExerciseStatus = iota
+	// ExerciseStatusClean means that the files haven't changed since the last submit.
+	ExerciseStatusClean
+	// ExerciseStatusModified means that the files changed since the last submit.
+	ExerciseStatusModified
+	// ExerciseStatusNeverSubmitted means that no solution has been submitted yet.
+	ExerciseStatusNeverSubmitted
+	// ExerciseStatusMissingFiles means that files of the exercise were deleted.
+	ExerciseStatusMissingFiles
+)
+
+func (s ExerciseStatus) String() string {
+	switch s {
+	case ExerciseStatusClean:
+		return "clean"
+	case ExerciseStatusModified:
+		return "modified"
+	case ExerciseStatusNeverSubmitted:
+		return "never submitted"
+	case ExerciseStatusMissingFiles:
+		return "missing files"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the status as its string, e.g. for JSON.
+func (s ExerciseStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// FileChecksum is the hex-encoded SHA-256 of a file's contents.
+func FileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RecordChecksums replaces the recorded checksums with those of the given files.
+// The paths are relative to the exercise directory, with forward slashes.
+// The metadata needs to be written for the checksums to be stored.
+func (em *ExerciseMetadata) RecordChecksums(paths []string) error {
+	checksums := make(map[string]string, len(paths))
+	for _, path := range paths {
+		checksum, err := FileChecksum(filepath.Join(em.Dir, filepath.FromSlash(path)))
+		if err != nil {
+			return err
+		}
+		checksums[path] = checksum
+	}
+	em.Checksums = checksums
+	return nil
+}
+
+// Status compares the exercise's files to the recorded checksums.
+// It returns the files that are missing or changed, if any.
+//
+// The files are those that were recorded, plus the solution files from the exercise config,
+// so that a new solution file counts as a modification.
+// Missing files take precedence over the submission state.
+func (em *ExerciseMetadata) Status() (ExerciseStatus, []string, error) {
+	paths := map[string]bool{}
+	for path := range em.Checksums {
+		paths[path] = true
+	}
+	if config, err := NewExerciseConfig(em.Dir); err == nil {
+		for _, path := range config.Files.Solution {
+			paths[filepath.ToSlash(path)] = true
+		}
+	}
+
+	var missing, changed []string
+	for path := range paths {
+		checksum, err := FileChecksum(filepath.Join(em.Dir, filepath.FromSlash(path)))
+		if os.IsNotExist(err) {
+			missing = append(missing, path)
+			continue
+		}
+		if err != nil {
+			return ExerciseStatusUnknown, nil, err
+		}
+		if checksum != em.Checksums[path] {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(changed)
+
+	switch {
+	case len(missing) > 0:
+		return ExerciseStatusMissingFiles, missing, nil
+	case em.SubmittedAt == nil:
+		return ExerciseStatusNeverSubmitted, changed, nil
+	case em.Checksums == nil:
+		return ExerciseStatusUnknown, nil, nil
+	case len(changed) > 0:
+		return ExerciseStatusModified, changed, nil
+	default:
+		return ExerciseStatusClean, nil, nil
+	}
+}
@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExerciseStatus(t *testing.T) {
+	dir, err := os.MkdirTemp("", "exercise-status")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, ".exercism"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["solution.go"]}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "solution.go"), []byte("package solution"), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "solution_test.go"), []byte("package solution"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	em := &ExerciseMetadata{Dir: dir}
+	status, _, err := em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusNeverSubmitted, status)
+
+	now := time.Now()
+	em.SubmittedAt = &now
+	status, _, err = em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusUnknown, status)
+
+	err = em.RecordChecksums([]string{"solution.go", "solution_test.go"})
+	assert.NoError(t, err)
+	status, files, err := em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusClean, status)
+	assert.Empty(t, files)
+
+	err = os.WriteFile(filepath.Join(dir, "solution.go"), []byte("package solution // changed"), os.FileMode(0644))
+	assert.NoError(t, err)
+	status, files, err = em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusModified, status)
+	assert.Equal(t, []string{"solution.go"}, files)
+
+	err = os.Remove(filepath.Join(dir, "solution_test.go"))
+	assert.NoError(t, err)
+	status, files, err = em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusMissingFiles, status)
+	assert.Equal(t, []string{"solution_test.go"}, files)
+}
+
+func TestExerciseStatusNewSolutionFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "exercise-status-new-file")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = os.MkdirAll(filepath.Join(dir, ".exercism"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{"files": {"solution": ["a.go", "b.go"]}}`), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "a.go"), []byte("a"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	em := &ExerciseMetadata{Dir: dir, SubmittedAt: &now}
+	err = em.RecordChecksums([]string{"a.go"})
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "b.go"), []byte("b"), os.FileMode(0644))
+	assert.NoError(t, err)
+	status, files, err := em.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, ExerciseStatusModified, status)
+	assert.Equal(t, []string{"b.go"}, files)
+}
@@ -0,0 +1,144 @@
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Where the git repository for auto-commits lives.
+const (
+	// GitScopeWorkspace keeps one repository for the whole workspace.
+	GitScopeWorkspace = "workspace"
+	// GitScopeTrack keeps a repository per track.
+	GitScopeTrack = "track"
+)
+
+// GitScopes are the valid scopes for auto-commits.
+var GitScopes = []string{GitScopeWorkspace, GitScopeTrack}
+
+// generatedPathspecs keep the files that the CLI writes as it goes out of commits:
+// cached test results, test logs and lock files, which change on every run.
+var generatedPathspecs = []string{
+	excludePathspec(testResultFilepath),
+	excludePathspec(testLogFilepath),
+	excludePathspec(filepath.Join(ignoreSubdir, "*.lock")),
+}
+
+func excludePathspec(path string) string {
+	return ":(exclude,glob)**/" + filepath.ToSlash(path)
+}
+
+// GitCommitter commits exercises to a git repository in the workspace.
+type GitCommitter struct {
+	// Root is the workspace directory.
+	Root string
+	// Scope is either GitScopeWorkspace or GitScopeTrack.
+	Scope string
+}
+
+// GitAvailable reports whether the git binary can be found.
+func GitAvailable() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// Commit records the current state of the exercise described by the metadata.
+// The repository is initialised if needed, unless the exercise already lives in one,
+// e.g. because the user keeps the workspace in git by hand.
+// Nothing is committed if the exercise hasn't changed.
+func (c GitCommitter) Commit(em *ExerciseMetadata, message string) error {
+	repo, err := c.repository(em)
+	if err != nil {
+		return err
+	}
+
+	pathspecs := append([]string{em.Dir}, generatedPathspecs...)
+	if _, err := git(repo, append([]string{"add", "--all", "--"}, pathspecs...)...); err != nil {
+		return err
+	}
+	if _, err := git(repo, "diff", "--cached", "--quiet", "--", em.Dir); err == nil {
+		// Nothing changed since the last commit.
+		return nil
+	}
+	_, err = git(repo, append([]string{"commit", "--quiet", "--message", message, "--"}, pathspecs...)...)
+	return err
+}
+
+// repository finds the repository for the exercise, initialising it if there isn't one.
+// An existing repository is only used if it is the workspace, or the track for the track scope.
+// Any other repository around the exercise, e.g. one for the dotfiles in the home directory, is refused.
+func (c GitCommitter) repository(em *ExerciseMetadata) (string, error) {
+	dir := c.Root
+	if c.Scope == GitScopeTrack {
+		// This is users/<handle>/<track> for other people's solutions.
+		dir = filepath.Dir(em.Dir)
+	}
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		if _, err := git(dir, "init", "--quiet"); err != nil {
+			return "", err
+		}
+		return dir, nil
+	}
+	top = filepath.FromSlash(top)
+	for _, allowed := range []string{dir, c.Root} {
+		if samePath(top, allowed) {
+			return top, nil
+		}
+	}
+	return "", fmt.Errorf("%s is inside the git repository %s, which isn't the repository for the workspace", dir, top)
+}
+
+// samePath reports whether both paths point at the same directory, following symlinks.
+func samePath(a, b string) bool {
+	a, errA := filepath.EvalSymlinks(a)
+	b, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && a == b
+}
+
+// git runs a git command in dir, and returns its trimmed output.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CommitMessage describes what happened to the exercise, e.g. "Submit go/two-fer (3rd submission from this machine)".
+// The count only covers this machine, so it can differ from the iteration number on the website.
+func (em *ExerciseMetadata) CommitMessage(action string) string {
+	if em.Iteration == 0 {
+		return fmt.Sprintf("%s %s", action, em)
+	}
+	return fmt.Sprintf("%s %s (%s submission from this machine)", action, em, ordinal(em.Iteration))
+}
+
+// ordinal formats n as an ordinal number, e.g. 1st, 2nd or 11th.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
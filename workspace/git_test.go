@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGitTest(t *testing.T) string {
+	if !GitAvailable() {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Exercism")
+	t.Setenv("GIT_AUTHOR_EMAIL", "cli@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Exercism")
+	t.Setenv("GIT_COMMITTER_EMAIL", "cli@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	tmpDir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NoError(t, err)
+	return tmpDir
+}
+
+func gitLog(t *testing.T, dir string) []string {
+	out, err := git(dir, "log", "--format=%s")
+	assert.NoError(t, err)
+	return strings.Split(out, "\n")
+}
+
+func TestGitCommitterWorkspaceScope(t *testing.T) {
+	tmpDir := setupGitTest(t)
+
+	em := writeTestExercise(t, filepath.Join(tmpDir, "bogus-track", "bogus-exercise"), ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+	for _, path := range []string{testResultFilepath, testLogFilepath, metadataFilepath + ".lock"} {
+		err := os.WriteFile(filepath.Join(em.Dir, path), []byte("{}"), os.FileMode(0644))
+		assert.NoError(t, err)
+	}
+
+	committer := GitCommitter{Root: tmpDir, Scope: GitScopeWorkspace}
+	err := committer.Commit(em, em.CommitMessage("Download"))
+	assert.NoError(t, err)
+	assert.DirExists(t, filepath.Join(tmpDir, ".git"))
+
+	files, err := git(tmpDir, "ls-files")
+	assert.NoError(t, err)
+	assert.Contains(t, files, "bogus-track/bogus-exercise/solution.txt")
+	assert.Contains(t, files, "bogus-track/bogus-exercise/.exercism/metadata.json")
+	assert.NotContains(t, files, testResultFilename)
+	assert.NotContains(t, files, TestLogFilename)
+	assert.NotContains(t, files, ".lock")
+
+	// Nothing changed, so there is nothing to commit.
+	err = committer.Commit(em, "Again")
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(em.Dir, "solution.txt"), []byte("improved"), os.FileMode(0644))
+	assert.NoError(t, err)
+	em.Iteration = 2
+	err = committer.Commit(em, em.CommitMessage("Submit"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Submit bogus-track/bogus-exercise (2nd submission from this machine)",
+		"Download bogus-track/bogus-exercise",
+	}, gitLog(t, tmpDir))
+}
+
+func TestGitCommitterTrackScope(t *testing.T) {
+	tmpDir := setupGitTest(t)
+
+	em := writeTestExercise(t, filepath.Join(tmpDir, "bogus-track", "bogus-exercise"), ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+
+	committer := GitCommitter{Root: tmpDir, Scope: GitScopeTrack}
+	err := committer.Commit(em, em.CommitMessage("Download"))
+	assert.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(tmpDir, ".git"))
+	assert.DirExists(t, filepath.Join(tmpDir, "bogus-track", ".git"))
+	assert.Equal(t, []string{"Download bogus-track/bogus-exercise"}, gitLog(t, filepath.Join(tmpDir, "bogus-track")))
+}
+
+func TestGitCommitterUsesExistingRepository(t *testing.T) {
+	tmpDir := setupGitTest(t)
+
+	_, err := git(tmpDir, "init", "--quiet")
+	assert.NoError(t, err)
+	em := writeTestExercise(t, filepath.Join(tmpDir, "bogus-track", "bogus-exercise"), ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+
+	committer := GitCommitter{Root: tmpDir, Scope: GitScopeTrack}
+	err = committer.Commit(em, em.CommitMessage("Download"))
+	assert.NoError(t, err)
+
+	assert.NoDirExists(t, filepath.Join(tmpDir, "bogus-track", ".git"))
+	assert.Equal(t, []string{"Download bogus-track/bogus-exercise"}, gitLog(t, tmpDir))
+}
+
+func TestGitCommitterRefusesEnclosingRepository(t *testing.T) {
+	tmpDir := setupGitTest(t)
+
+	// e.g. a repository for the dotfiles in the home directory
+	_, err := git(tmpDir, "init", "--quiet")
+	assert.NoError(t, err)
+	root := filepath.Join(tmpDir, "Exercism")
+	em := writeTestExercise(t, filepath.Join(root, "bogus-track", "bogus-exercise"), ExerciseMetadata{Track: "bogus-track", ExerciseSlug: "bogus-exercise", IsRequester: true})
+
+	committer := GitCommitter{Root: root, Scope: GitScopeWorkspace}
+	err = committer.Commit(em, em.CommitMessage("Download"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "isn't the repository for the workspace")
+	}
+
+	_, err = git(tmpDir, "rev-parse", "HEAD")
+	assert.Error(t, err, "nothing is committed to the enclosing repository")
+}
+
+func TestCommitMessage(t *testing.T) {
+	em := &ExerciseMetadata{Track: "go", ExerciseSlug: "two-fer"}
+	assert.Equal(t, "Download go/two-fer", em.CommitMessage("Download"))
+
+	testCases := []struct {
+		iteration int
+		expected  string
+	}{
+		{1, "Submit go/two-fer (1st submission from this machine)"},
+		{3, "Submit go/two-fer (3rd submission from this machine)"},
+		{11, "Submit go/two-fer (11th submission from this machine)"},
+		{22, "Submit go/two-fer (22nd submission from this machine)"},
+	}
+	for _, tc := range testCases {
+		em.Iteration = tc.iteration
+		assert.Equal(t, tc.expected, em.CommitMessage("Submit"))
+	}
+}
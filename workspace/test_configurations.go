@@ -1,11 +1,39 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"text/template"
 )
 
+// TestCommandSource identifies where a test command was configured.
+type TestCommandSource int
+
+// TestCommandSource, in increasing order of precedence.
+const (
+	TestCommandSourceBuiltin TestCommandSource = iota
+	TestCommandSourceExerciseConfig
+	TestCommandSourceUserTrack
+	TestCommandSourceUserExercise
+)
+
+func (s TestCommandSource) String() string {
+	switch s {
+	case TestCommandSourceExerciseConfig:
+		return "exercise config"
+	case TestCommandSourceUserTrack:
+		return "user config (track)"
+	case TestCommandSourceUserExercise:
+		return "user config (exercise)"
+	default:
+		return "built-in"
+	}
+}
+
 type TestConfiguration struct {
 	// The static portion of the test Command, which will be run for every test on this track. Examples include `cargo test` or `go test`.
 	// Might be empty if there are platform-specific versions
@@ -13,9 +41,85 @@ type TestConfiguration struct {
 
 	// Windows-specific test command. Mostly relevant for tests wrapped by shell invocations. Falls back to `Command` if we're not running windows or this is empty.
 	WindowsCommand string
+
+	// FilterArgs are appended to the command to run only the tests that match a pattern.
+	// Every occurrence of FilterPattern within them is replaced by the pattern.
+	// Empty if the track's test runner can't select tests.
+	FilterArgs []string
+
+	// Source records where the command came from. It is the zero value (built-in) for everything in TestConfigurations.
+	Source TestCommandSource
+}
+
+// FilterPattern is the placeholder for the user's pattern in FilterArgs.
+const FilterPattern = "{{pattern}}"
+
+// ErrFilterUnsupported signals that a test command can't run a subset of the tests.
+var ErrFilterUnsupported = errors.New("the test command does not support selecting tests")
+
+// GetFilterArgs returns the arguments that select the tests matching pattern.
+func (c *TestConfiguration) GetFilterArgs(pattern string) ([]string, error) {
+	if len(c.FilterArgs) == 0 {
+		return nil, ErrFilterUnsupported
+	}
+	args := make([]string, 0, len(c.FilterArgs))
+	for _, arg := range c.FilterArgs {
+		args = append(args, strings.ReplaceAll(arg, FilterPattern, pattern))
+	}
+	return args, nil
 }
 
+// LookupTestConfiguration finds the test configuration for the exercise in dir.
+// The most specific command wins, in this order:
+//
+//   - the user config, keyed by "<track>/<slug>"
+//   - the user config, keyed by "<track>"
+//   - the `test_command` in the exercise's .exercism/config.json
+//   - the built-in TestConfigurations
+//
+// The second return value is false if no source provides a command.
+//
+// Overridden commands have no FilterArgs, since they may run a different test runner.
+// They can select tests with {{pattern}} instead, see RenderFilteredTestCommand.
+func LookupTestConfiguration(dir, track, slug string, userCommands map[string]string) (TestConfiguration, bool) {
+	if command := userCommands[track+"/"+slug]; command != "" {
+		return TestConfiguration{Command: command, Source: TestCommandSourceUserExercise}, true
+	}
+	if command := userCommands[track]; command != "" {
+		return TestConfiguration{Command: command, Source: TestCommandSourceUserTrack}, true
+	}
+	// A missing or broken exercise config is not an error here.
+	// It will be reported if the command needs any of its values.
+	if exerciseConfig, err := NewExerciseConfig(dir); err == nil && exerciseConfig.TestCommand != "" {
+		return TestConfiguration{Command: exerciseConfig.TestCommand, Source: TestCommandSourceExerciseConfig}, true
+	}
+	testConf, ok := TestConfigurations[track]
+	return testConf, ok
+}
+
+// GetTestCommand renders the test command for the exercise in the current directory.
 func (c *TestConfiguration) GetTestCommand() (string, error) {
+	return c.RenderTestCommand(".")
+}
+
+// RenderTestCommand renders the test command for the exercise in dir.
+//
+// Commands are text/template templates. The legacy placeholders
+// {{solution_files}}, {{test_files}} and {{slug}} are functions that
+// return space-separated values. The fields and methods of TestCommandVars
+// are available as well, e.g. {{.PascalCase}} or {{.ExerciseDir}},
+// along with {{env "NAME"}} to read an environment variable.
+// Conditionals work as usual: {{if eq .OS "windows"}}...{{else}}...{{end}}.
+func (c *TestConfiguration) RenderTestCommand(dir string) (string, error) {
+	cmd, _, err := c.RenderFilteredTestCommand(dir, "")
+	return cmd, err
+}
+
+// RenderFilteredTestCommand renders the test command for the exercise in dir, like RenderTestCommand,
+// with {{pattern}} standing for the pattern of the tests to run. The pattern is empty to run all of them,
+// so a command usually wraps it in a conditional, e.g. "pytest{{with pattern}} -k {{.}}{{end}}".
+// The second return value reports whether the command uses the pattern.
+func (c *TestConfiguration) RenderFilteredTestCommand(dir, pattern string) (string, bool, error) {
 	var cmd string
 	if runtime.GOOS == "windows" && c.WindowsCommand != "" {
 		cmd = c.WindowsCommand
@@ -23,47 +127,176 @@ func (c *TestConfiguration) GetTestCommand() (string, error) {
 		cmd = c.Command
 	}
 
-	// pre-declare these so we can conditionally initialize them
-	var exerciseConfig *ExerciseConfig
-	var err error
+	if !strings.Contains(cmd, "{{") {
+		return cmd, false, nil
+	}
 
-	if strings.Contains(cmd, "{{") {
-		// only read exercise's config.json if we need it
-		exerciseConfig, err = NewExerciseConfig(".")
-		if err != nil {
-			return "", err
-		}
+	vars := newTestCommandVars(dir)
+	vars.pattern = pattern
+	tmpl, err := template.New("test command").Funcs(vars.funcs()).Parse(cmd)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid test command %q: %w", cmd, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", false, err
 	}
+	return b.String(), vars.patternUsed, nil
+}
 
-	if strings.Contains(cmd, "{{solution_files}}") {
-		if exerciseConfig == nil {
-			return "", fmt.Errorf("exerciseConfig not initialize before use")
-		}
-		solutionFiles, err := exerciseConfig.GetSolutionFiles()
+// FileList is a list of exercise files.
+// It renders as a space-separated list in a test command.
+type FileList []string
+
+func (fl FileList) String() string {
+	return strings.Join(fl, " ")
+}
+
+// TestCommandVars are the values available to a test command template.
+// The exercise's config and metadata are read only when a template asks for them.
+type TestCommandVars struct {
+	// OS is the operating system, as in runtime.GOOS.
+	OS string
+	// Arch is the architecture, as in runtime.GOARCH.
+	Arch string
+
+	dir         string
+	config      *ExerciseConfig
+	metadata    *ExerciseMetadata
+	pattern     string
+	patternUsed bool
+}
+
+func newTestCommandVars(dir string) *TestCommandVars {
+	return &TestCommandVars{
+		OS:   runtime.GOOS,
+		Arch: runtime.GOARCH,
+		dir:  dir,
+	}
+}
+
+func (v *TestCommandVars) funcs() template.FuncMap {
+	return template.FuncMap{
+		"solution_files": func() (string, error) {
+			files, err := v.SolutionFiles()
+			return files.String(), err
+		},
+		"test_files": func() (string, error) {
+			files, err := v.TestFiles()
+			return files.String(), err
+		},
+		"slug": v.Slug,
+		"env":  os.Getenv,
+		"pattern": func() string {
+			v.patternUsed = true
+			return v.pattern
+		},
+	}
+}
+
+func (v *TestCommandVars) exerciseConfig() (*ExerciseConfig, error) {
+	if v.config == nil {
+		config, err := NewExerciseConfig(v.dir)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		cmd = strings.ReplaceAll(cmd, "{{solution_files}}", strings.Join(solutionFiles, " "))
+		v.config = config
 	}
-	if strings.Contains(cmd, "{{test_files}}") {
-		if exerciseConfig == nil {
-			return "", fmt.Errorf("exerciseConfig not initialize before use")
-		}
-		testFiles, err := exerciseConfig.GetTestFiles()
+	return v.config, nil
+}
+
+func (v *TestCommandVars) exerciseMetadata() (*ExerciseMetadata, error) {
+	if v.metadata == nil {
+		metadata, err := NewExerciseMetadata(v.dir)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		cmd = strings.ReplaceAll(cmd, "{{test_files}}", strings.Join(testFiles, " "))
+		v.metadata = metadata
 	}
-	if strings.Contains(cmd, "{{slug}}") {
-		metadata, err := NewExerciseMetadata(".")
-		if err != nil {
-			return "", err
+	return v.metadata, nil
+}
+
+// ExerciseDir is the absolute path to the exercise.
+func (v *TestCommandVars) ExerciseDir() (string, error) {
+	return filepath.Abs(v.dir)
+}
+
+// Track is the track the exercise belongs to.
+func (v *TestCommandVars) Track() (string, error) {
+	metadata, err := v.exerciseMetadata()
+	if err != nil {
+		return "", err
+	}
+	return metadata.Track, nil
+}
+
+// Slug is the exercise slug, e.g. "two-fer".
+func (v *TestCommandVars) Slug() (string, error) {
+	metadata, err := v.exerciseMetadata()
+	if err != nil {
+		return "", err
+	}
+	return metadata.ExerciseSlug, nil
+}
+
+// SnakeCase is the exercise slug in snake_case, e.g. "two_fer".
+func (v *TestCommandVars) SnakeCase() (string, error) {
+	slug, err := v.Slug()
+	return strings.ReplaceAll(slug, "-", "_"), err
+}
+
+// PascalCase is the exercise slug in PascalCase, e.g. "TwoFer".
+func (v *TestCommandVars) PascalCase() (string, error) {
+	slug, err := v.Slug()
+	if err != nil {
+		return "", err
+	}
+	words := strings.Split(slug, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
 		}
-		cmd = strings.ReplaceAll(cmd, "{{slug}}", metadata.ExerciseSlug)
 	}
+	return strings.Join(words, ""), nil
+}
 
-	return cmd, nil
+// CamelCase is the exercise slug in camelCase, e.g. "twoFer".
+func (v *TestCommandVars) CamelCase() (string, error) {
+	pascal, err := v.PascalCase()
+	if err != nil || pascal == "" {
+		return pascal, err
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:], nil
+}
+
+// SolutionFiles are the files the student is expected to edit.
+func (v *TestCommandVars) SolutionFiles() (FileList, error) {
+	config, err := v.exerciseConfig()
+	if err != nil {
+		return nil, err
+	}
+	files, err := config.GetSolutionFiles()
+	return FileList(files), err
+}
+
+// TestFiles are the files that hold the exercise's tests.
+func (v *TestCommandVars) TestFiles() (FileList, error) {
+	config, err := v.exerciseConfig()
+	if err != nil {
+		return nil, err
+	}
+	files, err := config.GetTestFiles()
+	return FileList(files), err
+}
+
+// EditorFiles are read-only support files, such as stubs or helpers.
+// It is empty if the exercise has none.
+func (v *TestCommandVars) EditorFiles() (FileList, error) {
+	config, err := v.exerciseConfig()
+	if err != nil {
+		return nil, err
+	}
+	return FileList(config.Files.Editor), nil
 }
 
 // some tracks aren't (or won't be) implemented; every track is listed either way
@@ -115,17 +348,20 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "make",
 	},
 	"crystal": {
-		Command: "crystal spec",
+		Command:    "crystal spec",
+		FilterArgs: []string{"-e", FilterPattern},
 	},
 	"csharp": {
-		Command: "dotnet test",
+		Command:    "dotnet test",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	"d": {
 		// this always works even if the user installed DUB
 		Command: "dmd source/*.d -de -w -main -unittest",
 	},
 	"dart": {
-		Command: "dart test",
+		Command:    "dart test",
+		FilterArgs: []string{"--name", FilterPattern},
 	},
 	// delphi: tests are run via IDE
 	"elixir": {
@@ -150,7 +386,8 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "make test=all",
 	},
 	"fsharp": {
-		Command: "dotnet test",
+		Command:    "dotnet test",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	"futhark": {
 		Command: "futhark test test.fut",
@@ -159,7 +396,8 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "gleam test",
 	},
 	"go": {
-		Command: "go test",
+		Command:    "go test",
+		FilterArgs: []string{"-run", FilterPattern},
 	},
 	"groovy": {
 		Command: "gradle test",
@@ -176,9 +414,11 @@ var TestConfigurations = map[string]TestConfiguration{
 	"java": {
 		Command:        "./gradlew test",
 		WindowsCommand: "gradlew.bat test",
+		FilterArgs:     []string{"--tests", FilterPattern},
 	},
 	"javascript": {
-		Command: "npm run test",
+		Command:    "npm run test",
+		FilterArgs: []string{"--", "-t", FilterPattern},
 	},
 	"jq": {
 		Command: "bats {{test_files}}",
@@ -189,6 +429,7 @@ var TestConfigurations = map[string]TestConfiguration{
 	"kotlin": {
 		Command:        "./gradlew test",
 		WindowsCommand: "gradlew.bat test",
+		FilterArgs:     []string{"--tests", FilterPattern},
 	},
 	"lean": {
 		Command: "lake test",
@@ -197,13 +438,15 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "make test",
 	},
 	"lua": {
-		Command: "busted",
+		Command:    "busted",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	"mips": {
 		Command: "java -jar /path/to/mars.jar nc runner.mips impl.mips",
 	},
 	"moonscript": {
-		Command: "busted",
+		Command:    "busted",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	"nim": {
 		Command: "nim r {{test_files}}",
@@ -236,7 +479,8 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "pyret {{test_files}}",
 	},
 	"python": {
-		Command: "python3 -m pytest -o markers=task {{test_files}}",
+		Command:    "python3 -m pytest -o markers=task {{test_files}}",
+		FilterArgs: []string{"-k", FilterPattern},
 	},
 	"r": {
 		Command: "Rscript {{test_files}}",
@@ -257,10 +501,12 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "roc test {{test_files}}",
 	},
 	"ruby": {
-		Command: "ruby {{test_files}}",
+		Command:    "ruby {{test_files}}",
+		FilterArgs: []string{"-n", "/" + FilterPattern + "/"},
 	},
 	"rust": {
-		Command: "cargo test --",
+		Command:    "cargo test --",
+		FilterArgs: []string{FilterPattern},
 	},
 	"scala": {
 		Command: "sbt test",
@@ -270,20 +516,23 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "poly -q --use {{test_files}}",
 	},
 	"swift": {
-		Command: "swift test",
+		Command:    "swift test",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	"tcl": {
 		Command: "tclsh {{test_files}}",
 	},
 	"typescript": {
-		Command: "yarn test",
+		Command:    "yarn test",
+		FilterArgs: []string{"-t", FilterPattern},
 	},
 	"uiua": {
 		Command: "uiua test {{test_files}}",
 	},
 	// unison: tests are run from an active UCM session
 	"vbnet": {
-		Command: "dotnet test",
+		Command:    "dotnet test",
+		FilterArgs: []string{"--filter", FilterPattern},
 	},
 	// vimscript: tests are run from inside a vim session
 	"vlang": {
@@ -302,6 +551,7 @@ var TestConfigurations = map[string]TestConfiguration{
 		Command: "make test",
 	},
 	"zig": {
-		Command: "zig test {{test_files}}",
+		Command:    "zig test {{test_files}}",
+		FilterArgs: []string{"--test-filter", FilterPattern},
 	},
 }
@@ -141,3 +141,166 @@ func TestIdrisUsesExerciseSlug(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, cmd, "pack test bogus-exercise")
 }
+
+func TestLookupTestConfigurationPrecedence(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-command-precedence")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	err = os.Mkdir(filepath.Join(tmpDir, ".exercism"), os.ModePerm)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDir, ".exercism", "config.json"), []byte(`{ "test_command": "pytest --exercise-config" }`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		userCommands map[string]string
+		command      string
+		source       TestCommandSource
+	}{
+		{
+			desc:         "exercise config beats built-in",
+			userCommands: nil,
+			command:      "pytest --exercise-config",
+			source:       TestCommandSourceExerciseConfig,
+		},
+		{
+			desc:         "user track beats exercise config",
+			userCommands: map[string]string{"python": "pytest -x"},
+			command:      "pytest -x",
+			source:       TestCommandSourceUserTrack,
+		},
+		{
+			desc:         "user exercise beats user track",
+			userCommands: map[string]string{"python": "pytest -x", "python/two-fer": "pytest -x -v"},
+			command:      "pytest -x -v",
+			source:       TestCommandSourceUserExercise,
+		},
+		{
+			desc:         "other exercises are ignored",
+			userCommands: map[string]string{"python/leap": "pytest -x -v"},
+			command:      "pytest --exercise-config",
+			source:       TestCommandSourceExerciseConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			testConf, ok := LookupTestConfiguration(tmpDir, "python", "two-fer", tc.userCommands)
+			assert.True(t, ok)
+			assert.Equal(t, tc.command, testConf.Command)
+			assert.Equal(t, tc.source, testConf.Source)
+		})
+	}
+}
+
+func TestLookupTestConfigurationBuiltin(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-command-builtin")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	testConf, ok := LookupTestConfiguration(tmpDir, "elixir", "lasagna", nil)
+	assert.True(t, ok)
+	assert.Equal(t, "mix test", testConf.Command)
+	assert.Equal(t, TestCommandSourceBuiltin, testConf.Source)
+
+	_, ok = LookupTestConfiguration(tmpDir, "abap", "hello-world", nil)
+	assert.False(t, ok)
+
+	testConf, ok = LookupTestConfiguration(tmpDir, "abap", "hello-world", map[string]string{"abap": "abaplint"})
+	assert.True(t, ok)
+	assert.Equal(t, "abaplint", testConf.Command)
+}
+
+func TestRenderTestCommandTemplate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-command-template")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	em := &ExerciseMetadata{Track: "java", ExerciseSlug: "rna-transcription"}
+	err = em.Write(tmpDir)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDir, ".exercism", "config.json"), []byte(`{ "files": { "solution": ["src/main/java/RnaTranscription.java"], "test": ["src/test/java/RnaTranscriptionTest.java"], "editor": ["src/main/java/Nucleotide.java"] } }`), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	os.Setenv("EXERCISM_TEMPLATE_TEST", "from-env")
+	defer os.Unsetenv("EXERCISM_TEMPLATE_TEST")
+
+	testCases := []struct {
+		command, expected string
+	}{
+		{"run {{.Track}} {{.Slug}}", "run java rna-transcription"},
+		{"{{.SnakeCase}} {{.PascalCase}} {{.CamelCase}}", "rna_transcription RnaTranscription rnaTranscription"},
+		{"check {{.EditorFiles}}", "check src/main/java/Nucleotide.java"},
+		{"{{range .TestFiles}}--test {{.}} {{end}}", "--test src/test/java/RnaTranscriptionTest.java "},
+		{"gradle test --tests {{.PascalCase}}Test", "gradle test --tests RnaTranscriptionTest"},
+		{`echo {{env "EXERCISM_TEMPLATE_TEST"}}`, "echo from-env"},
+		{"{{.OS}}/{{.Arch}}", runtime.GOOS + "/" + runtime.GOARCH},
+		{`{{if eq .OS "plan9"}}rc{{else}}sh{{end}} test.sh`, "sh test.sh"},
+		{"cd {{.ExerciseDir}}", "cd " + tmpDir},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.command, func(t *testing.T) {
+			testConf := TestConfiguration{Command: tc.command}
+			cmd, err := testConf.RenderTestCommand(tmpDir)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, cmd)
+		})
+	}
+}
+
+func TestRenderTestCommandInvalidTemplate(t *testing.T) {
+	testConf := TestConfiguration{Command: "run {{.Slug"}
+	_, err := testConf.RenderTestCommand(".")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid test command")
+}
+
+func TestGetFilterArgs(t *testing.T) {
+	testCases := []struct {
+		track    string
+		expected []string
+	}{
+		{"go", []string{"-run", "TestLeap"}},
+		{"python", []string{"-k", "TestLeap"}},
+		{"rust", []string{"TestLeap"}},
+		{"ruby", []string{"-n", "/TestLeap/"}},
+		{"javascript", []string{"--", "-t", "TestLeap"}},
+	}
+	for _, tc := range testCases {
+		testConf := TestConfigurations[tc.track]
+		args, err := testConf.GetFilterArgs("TestLeap")
+		assert.NoError(t, err, tc.track)
+		assert.Equal(t, tc.expected, args, tc.track)
+	}
+
+	testConf := TestConfigurations["c"]
+	_, err := testConf.GetFilterArgs("TestLeap")
+	assert.Equal(t, ErrFilterUnsupported, err)
+
+	// overrides may run a different test runner, so they don't inherit the track's filter
+	testConf, ok := LookupTestConfiguration(".", "python", "leap", map[string]string{"python": "pytest -x"})
+	assert.True(t, ok)
+	_, err = testConf.GetFilterArgs("test_leap")
+	assert.Equal(t, ErrFilterUnsupported, err)
+}
+
+func TestRenderFilteredTestCommand(t *testing.T) {
+	testConf := TestConfiguration{Command: "pytest -x{{with pattern}} -k {{.}}{{end}}"}
+
+	cmd, used, err := testConf.RenderFilteredTestCommand(".", "test_leap")
+	assert.NoError(t, err)
+	assert.True(t, used)
+	assert.Equal(t, "pytest -x -k test_leap", cmd)
+
+	cmd, err = testConf.RenderTestCommand(".")
+	assert.NoError(t, err)
+	assert.Equal(t, "pytest -x", cmd)
+
+	testConf = TestConfiguration{Command: "pytest -x"}
+	cmd, used, err = testConf.RenderFilteredTestCommand(".", "test_leap")
+	assert.NoError(t, err)
+	assert.False(t, used)
+	assert.Equal(t, "pytest -x", cmd)
+}
@@ -0,0 +1,138 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/exercism/cli/atomicfile"
+)
+
+const testResultFilename = "test-result.json"
+
+var testResultFilepath = filepath.Join(ignoreSubdir, testResultFilename)
+
+// TestLogFilename is the name of the file in an exercise's .exercism
+// directory that holds the output of the last test run of `test --all`.
+const TestLogFilename = "test.log"
+
+var testLogFilepath = filepath.Join(ignoreSubdir, TestLogFilename)
+
+// TestResult is the outcome of the last test run of an exercise.
+// It is stored next to the exercise metadata, so that the tests
+// don't need to run again if nothing has changed.
+type TestResult struct {
+	// Hash fingerprints the inputs of the test run. See TestInputsHash.
+	Hash     string        `json:"hash"`
+	ExitCode int           `json:"exit_code"`
+	Duration time.Duration `json:"duration"`
+	RanAt    time.Time     `json:"ran_at"`
+	// Output is empty if the output went straight to the terminal.
+	Output string `json:"output,omitempty"`
+}
+
+// NewTestResult reads the last test result from the given exercise directory.
+func NewTestResult(dir string) (*TestResult, error) {
+	b, err := os.ReadFile(filepath.Join(dir, testResultFilepath))
+	if err != nil {
+		return nil, err
+	}
+	var result TestResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Write stores the test result in the given exercise directory.
+func (tr *TestResult) Write(dir string) error {
+	b, err := json.Marshal(tr)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, testResultFilepath)
+	if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, b, os.FileMode(0600))
+}
+
+// TestRun is how the tests are run, as far as it can change their result.
+type TestRun struct {
+	// Command is the test command, followed by its arguments.
+	Command []string
+	// Sandbox is whether the command runs in the sandbox, e.g. without network access.
+	Sandbox bool
+	// Env is the environment the command runs in, as returned by os.Environ.
+	Env []string
+}
+
+// volatileEnvVars are set by the shell, and differ between runs without affecting the tests.
+// The test command gets its own PWD, the exercise directory.
+var volatileEnvVars = map[string]bool{
+	"PWD":    true,
+	"OLDPWD": true,
+	"SHLVL":  true,
+	"_":      true,
+}
+
+// TestInputsHash fingerprints everything a test run depends on:
+// how it's run, and the contents of the exercise's solution and test files.
+// It fails if the exercise config doesn't list the files.
+func TestInputsHash(dir string, run TestRun) (string, error) {
+	config, err := NewExerciseConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	solutionFiles, err := config.GetSolutionFiles()
+	if err != nil {
+		return "", err
+	}
+	testFiles, err := config.GetTestFiles()
+	if err != nil {
+		return "", err
+	}
+	files := append(append([]string{}, solutionFiles...), testFiles...)
+	sort.Strings(files)
+
+	env := make([]string, 0, len(run.Env))
+	for _, v := range run.Env {
+		if name, _, _ := strings.Cut(v, "="); !volatileEnvVars[name] {
+			env = append(env, v)
+		}
+	}
+	sort.Strings(env)
+
+	h := sha256.New()
+	for _, arg := range run.Command {
+		fmt.Fprintf(h, "arg %q\n", arg)
+	}
+	fmt.Fprintf(h, "sandbox %t\n", run.Sandbox)
+	for _, v := range env {
+		fmt.Fprintf(h, "env %q\n", v)
+	}
+	for _, file := range files {
+		fmt.Fprintf(h, "file %q\n", file)
+		f, err := os.Open(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			fmt.Fprintf(h, "missing\n")
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
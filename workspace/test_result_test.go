@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestResultRoundTrip(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-result")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewTestResult(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	result := &TestResult{
+		Hash:     "abc",
+		ExitCode: 1,
+		Duration: 3 * time.Second,
+		RanAt:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Output:   "1 failed",
+	}
+	err = result.Write(dir)
+	assert.NoError(t, err)
+
+	stored, err := NewTestResult(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, result, stored)
+}
+
+func TestTestInputsHash(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-inputs-hash")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.Error(t, err, "no exercise config")
+
+	err = os.Mkdir(filepath.Join(dir, ".exercism"), os.ModePerm)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, ".exercism", "config.json"), []byte(`{ "files": { "solution": ["leap.go"], "test": ["leap_test.go"] } }`), os.FileMode(0600))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "leap_test.go"), []byte("package leap"), os.FileMode(0600))
+	assert.NoError(t, err)
+
+	missing, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "leap.go"), []byte("package leap"), os.FileMode(0600))
+	assert.NoError(t, err)
+	original, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, missing, original)
+
+	same, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.NoError(t, err)
+	assert.Equal(t, original, same)
+
+	otherCommand, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test", "-v"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, original, otherCommand)
+
+	sandboxed, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}, Sandbox: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, original, sandboxed)
+
+	otherEnv, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}, Env: []string{"GOFLAGS=-race"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, original, otherEnv)
+
+	// the order of the environment, and the variables the shell changes all the time, don't matter
+	env, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}, Env: []string{"B=2", "A=1", "PWD=/tmp"}})
+	assert.NoError(t, err)
+	sameEnv, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}, Env: []string{"A=1", "B=2", "OLDPWD=/", "SHLVL=2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, env, sameEnv)
+
+	// files that aren't part of the solution or tests don't matter
+	err = os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("todo"), os.FileMode(0600))
+	assert.NoError(t, err)
+	unrelated, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.NoError(t, err)
+	assert.Equal(t, original, unrelated)
+
+	err = os.WriteFile(filepath.Join(dir, "leap.go"), []byte("package leap\n\nfunc IsLeapYear(int) bool { return false }"), os.FileMode(0600))
+	assert.NoError(t, err)
+	edited, err := TestInputsHash(dir, TestRun{Command: []string{"go", "test"}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, original, edited)
+}
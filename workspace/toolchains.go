@@ -0,0 +1,187 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// Tool is an executable that a track's test command depends on.
+type Tool struct {
+	// Name is the executable, as it is found on the PATH.
+	Name string
+
+	// MinVersion is the oldest supported version. Empty if any version will do.
+	MinVersion string
+
+	// VersionArgs makes the executable print its version. Defaults to `--version`.
+	VersionArgs []string
+}
+
+// Toolchain lists the tools that need to be installed to run a track's tests.
+type Toolchain struct {
+	Tools []Tool
+}
+
+// ToolProblem explains why a tool can't be used.
+type ToolProblem struct {
+	Tool Tool
+	// Version is the version that was found, if the tool is installed but too old.
+	Version string
+	// TimedOut is true if the tool didn't print its version within VersionTimeout.
+	TimedOut bool
+}
+
+func (p ToolProblem) String() string {
+	if p.TimedOut {
+		return fmt.Sprintf("%s (didn't report its version within %s)", p.Tool.Name, VersionTimeout)
+	}
+	if p.Version == "" {
+		return fmt.Sprintf("%s (not found)", p.Tool.Name)
+	}
+	return fmt.Sprintf("%s %s (version %s or newer is required)", p.Tool.Name, p.Version, p.Tool.MinVersion)
+}
+
+var versionRe = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// VersionTimeout is how long a tool gets to print its version,
+// so that one that waits for input or hangs can't stall the check.
+var VersionTimeout = 10 * time.Second
+
+// Check looks for each of the tools on the PATH and probes their versions.
+// It returns the tools that are missing or too old.
+// A version that can't be determined is given the benefit of the doubt.
+func (tc Toolchain) Check() []ToolProblem {
+	var problems []ToolProblem
+	for _, tool := range tc.Tools {
+		path, err := exec.LookPath(tool.Name)
+		if err != nil {
+			problems = append(problems, ToolProblem{Tool: tool})
+			continue
+		}
+		if tool.MinVersion == "" {
+			continue
+		}
+		args := tool.VersionArgs
+		if len(args) == 0 {
+			args = []string{"--version"}
+		}
+		out, err := probeVersion(path, args)
+		if err == context.DeadlineExceeded {
+			problems = append(problems, ToolProblem{Tool: tool, TimedOut: true})
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		found := versionRe.FindString(string(out))
+		version, err := semver.ParseTolerant(found)
+		if err != nil {
+			continue
+		}
+		minVersion, err := semver.ParseTolerant(tool.MinVersion)
+		if err != nil {
+			continue
+		}
+		if version.LT(minVersion) {
+			problems = append(problems, ToolProblem{Tool: tool, Version: found})
+		}
+	}
+	return problems
+}
+
+// probeVersion runs the tool to print its version, and returns what it printed.
+// The error is context.DeadlineExceeded if it takes longer than VersionTimeout.
+func probeVersion(path string, args []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), VersionTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, args...)
+	// Don't wait for children of the tool that keep the output open after it has been killed.
+	cmd.WaitDelay = time.Second
+	// Some tools, such as java, print their version to stderr.
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, ctx.Err()
+	}
+	return out, err
+}
+
+// InstallURL is the page on the website that explains how to install a track's toolchain.
+func InstallURL(track string) string {
+	return fmt.Sprintf("https://exercism.org/docs/tracks/%s/installation", track)
+}
+
+// LookupToolchain determines what needs to be installed to run a test command.
+// This is the executable that the command runs, plus anything the registry
+// lists for the track. The registry is only consulted for built-in test commands.
+func LookupToolchain(track string, testConf TestConfiguration, executable string) Toolchain {
+	var toolchain Toolchain
+	if testConf.Source == TestCommandSourceBuiltin {
+		toolchain.Tools = append(toolchain.Tools, Toolchains[track].Tools...)
+	}
+	// Relative paths such as ./gradlew are part of the exercise, not the toolchain.
+	if executable == "" || strings.ContainsAny(executable, `/\`) {
+		return toolchain
+	}
+	for _, tool := range toolchain.Tools {
+		if tool.Name == executable {
+			return toolchain
+		}
+	}
+	toolchain.Tools = append([]Tool{{Name: executable}}, toolchain.Tools...)
+	return toolchain
+}
+
+// Toolchains lists what the built-in test commands need, per track, besides the executable they run.
+// An entry for the executable itself adds a minimum version.
+var Toolchains = map[string]Toolchain{
+	"awk": {
+		Tools: []Tool{{Name: "gawk"}},
+	},
+	"cobol": {
+		Tools: []Tool{{Name: "cobc"}},
+	},
+	"elixir": {
+		Tools: []Tool{{Name: "erl"}},
+	},
+	"gleam": {
+		Tools: []Tool{{Name: "erl"}},
+	},
+	"go": {
+		Tools: []Tool{{Name: "go", MinVersion: "1.18", VersionArgs: []string{"version"}}},
+	},
+	"java": {
+		// gradlew downloads gradle itself, but it needs a JDK.
+		Tools: []Tool{{Name: "java"}},
+	},
+	"javascript": {
+		Tools: []Tool{{Name: "node"}},
+	},
+	"jq": {
+		Tools: []Tool{{Name: "jq"}},
+	},
+	"kotlin": {
+		Tools: []Tool{{Name: "java"}},
+	},
+	"python": {
+		Tools: []Tool{{Name: "python3", MinVersion: "3.7"}},
+	},
+	"reasonml": {
+		Tools: []Tool{{Name: "node"}},
+	},
+	"rust": {
+		// the exercises use the 2021 edition
+		Tools: []Tool{{Name: "cargo", MinVersion: "1.56"}},
+	},
+	"typescript": {
+		Tools: []Tool{{Name: "node"}},
+	},
+	"wasm": {
+		Tools: []Tool{{Name: "node"}},
+	},
+}
@@ -0,0 +1,114 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toolNames(toolchain Toolchain) []string {
+	names := make([]string, 0, len(toolchain.Tools))
+	for _, tool := range toolchain.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestLookupToolchain(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		track      string
+		testConf   TestConfiguration
+		executable string
+		expected   []string
+	}{
+		{
+			desc:       "unregistered track needs its executable",
+			track:      "crystal",
+			testConf:   TestConfigurations["crystal"],
+			executable: "crystal",
+			expected:   []string{"crystal"},
+		},
+		{
+			desc:       "registered track needs its executable and extra tools",
+			track:      "elixir",
+			testConf:   TestConfigurations["elixir"],
+			executable: "mix",
+			expected:   []string{"mix", "erl"},
+		},
+		{
+			desc:       "registry entry for the executable is not duplicated",
+			track:      "rust",
+			testConf:   TestConfigurations["rust"],
+			executable: "cargo",
+			expected:   []string{"cargo"},
+		},
+		{
+			desc:       "scripts within the exercise are not tools",
+			track:      "java",
+			testConf:   TestConfigurations["java"],
+			executable: "./gradlew",
+			expected:   []string{"java"},
+		},
+		{
+			desc:       "registry is ignored for overridden commands",
+			track:      "elixir",
+			testConf:   TestConfiguration{Command: "lein test", Source: TestCommandSourceUserTrack},
+			executable: "lein",
+			expected:   []string{"lein"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			toolchain := LookupToolchain(tc.track, tc.testConf, tc.executable)
+			assert.Equal(t, tc.expected, toolNames(toolchain))
+		})
+	}
+}
+
+func TestToolchainCheck(t *testing.T) {
+	toolchain := Toolchain{Tools: []Tool{
+		{Name: "go", MinVersion: "1.0", VersionArgs: []string{"version"}},
+		{Name: "exercism-bogus-tool-that-does-not-exist"},
+	}}
+	problems := toolchain.Check()
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, "exercism-bogus-tool-that-does-not-exist (not found)", problems[0].String())
+	}
+
+	toolchain = Toolchain{Tools: []Tool{
+		{Name: "go", MinVersion: "999.0", VersionArgs: []string{"version"}},
+	}}
+	problems = toolchain.Check()
+	if assert.Len(t, problems, 1) {
+		assert.NotEmpty(t, problems[0].Version)
+		assert.Contains(t, problems[0].String(), "version 999.0 or newer is required")
+	}
+}
+
+func TestToolchainCheckTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake tool is a shell script")
+	}
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "slow-tool"), []byte("#!/bin/sh\nsleep 10\n"), os.FileMode(0755))
+	assert.NoError(t, err)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	defer func(timeout time.Duration) { VersionTimeout = timeout }(VersionTimeout)
+	VersionTimeout = 100 * time.Millisecond
+
+	toolchain := Toolchain{Tools: []Tool{{Name: "slow-tool", MinVersion: "1.0"}}}
+	start := time.Now()
+	problems := toolchain.Check()
+	assert.Less(t, time.Since(start), 5*time.Second)
+	if assert.Len(t, problems, 1) {
+		assert.True(t, problems[0].TimedOut)
+		assert.Equal(t, "slow-tool (didn't report its version within 100ms)", problems[0].String())
+	}
+}
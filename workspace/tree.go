@@ -0,0 +1,156 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CopyTree copies the directory tree at src to dst, preserving modes, modification times and symlinks.
+// Directories are made writable by the owner, so that they can be filled.
+//
+// It can be run again after an interruption: files that were already copied,
+// judging by their size and modification time, are skipped.
+func CopyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case d.Type()&fs.ModeSymlink != 0:
+			return copySymlink(path, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info)
+		default:
+			return fmt.Errorf("can't copy %s: not a regular file, directory or symlink", path)
+		}
+	})
+}
+
+func copySymlink(src, dst string) error {
+	link, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	if existing, err := os.Readlink(dst); err == nil && existing == link {
+		return nil
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(link, dst)
+}
+
+func copyFile(src, dst string, info fs.FileInfo) error {
+	if existing, err := os.Lstat(dst); err == nil && existing.Mode().IsRegular() &&
+		existing.Size() == info.Size() && existing.ModTime().Equal(info.ModTime()) {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// A leftover copy may be read-only.
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm()|0200)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+	// The modification time marks the copy as complete.
+	return os.Chtimes(dst, info.ModTime(), info.ModTime())
+}
+
+// VerifyTree checks that dst holds the same files, symlinks and directories as src,
+// with the same contents.
+func VerifyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		targetInfo, err := os.Lstat(target)
+		if err != nil {
+			return fmt.Errorf("%s is missing: %w", target, err)
+		}
+		if info.Mode().Type() != targetInfo.Mode().Type() {
+			return fmt.Errorf("%s is not the same type of file as %s", target, path)
+		}
+
+		switch {
+		case d.IsDir():
+			return nil
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			targetLink, err := os.Readlink(target)
+			if err != nil {
+				return err
+			}
+			if link != targetLink {
+				return fmt.Errorf("%s points to %s instead of %s", target, targetLink, link)
+			}
+			return nil
+		default:
+			same, err := sameContents(path, target)
+			if err != nil {
+				return err
+			}
+			if !same {
+				return fmt.Errorf("%s differs from %s", target, path)
+			}
+			return nil
+		}
+	})
+}
+
+func sameContents(a, b string) (bool, error) {
+	checksumA, err := FileChecksum(a)
+	if err != nil {
+		return false, err
+	}
+	checksumB, err := FileChecksum(b)
+	if err != nil {
+		return false, err
+	}
+	return checksumA == checksumB, nil
+}
@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyTree(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "copy-tree")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	src := filepath.Join(tmpDir, "src")
+	dst := filepath.Join(tmpDir, "dst")
+	err = os.MkdirAll(filepath.Join(src, "track", "exercise"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(src, "track", "exercise", "solution.txt"), []byte("solution"), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(src, "track", "exercise", "read-only.txt"), []byte("read-only"), os.FileMode(0444))
+	assert.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		err = os.Symlink("solution.txt", filepath.Join(src, "track", "exercise", "link.txt"))
+		assert.NoError(t, err)
+	}
+
+	// A partial copy from an interrupted attempt.
+	err = os.MkdirAll(filepath.Join(dst, "track", "exercise"), os.FileMode(0755))
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dst, "track", "exercise", "solution.txt"), []byte("sol"), os.FileMode(0644))
+	assert.NoError(t, err)
+
+	err = VerifyTree(src, dst)
+	assert.Error(t, err)
+
+	err = CopyTree(src, dst)
+	assert.NoError(t, err)
+	err = VerifyTree(src, dst)
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(dst, "track", "exercise", "solution.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "solution", string(b))
+	info, err := os.Stat(filepath.Join(dst, "track", "exercise", "read-only.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0444), info.Mode().Perm())
+
+	// Copying again is a noop.
+	err = CopyTree(src, dst)
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dst, "track", "exercise", "solution.txt"), []byte("changed!"), os.FileMode(0644))
+	assert.NoError(t, err)
+	err = VerifyTree(src, dst)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "differs from")
+	}
+}
@@ -96,6 +96,63 @@ func (ws Workspace) Exercises() ([]Exercise, error) {
 	return exercises, nil
 }
 
+// CommunityExercises returns other people's exercises within the workspace.
+// They live in users/<handle>/<track>/<slug>, and are only found if they have metadata.
+func (ws Workspace) CommunityExercises() ([]Exercise, error) {
+	usersDir := filepath.Join(ws.Dir, "users")
+	handleInfos, err := os.ReadDir(usersDir)
+	if os.IsNotExist(err) {
+		return []Exercise{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exercises := []Exercise{}
+	for _, handleInfo := range handleInfos {
+		if !handleInfo.IsDir() {
+			continue
+		}
+		userWorkspace := Workspace{Dir: filepath.Join(usersDir, handleInfo.Name())}
+		candidates, err := userWorkspace.PotentialExercises()
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			ok, err := candidate.HasMetadata()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				exercises = append(exercises, candidate)
+			}
+		}
+	}
+	return exercises, nil
+}
+
+// FindExercises finds the user's exercises that match an exercise ID.
+// The ID is either a slug, such as "two-fer", or a track and a slug,
+// such as "go/two-fer".
+func (ws Workspace) FindExercises(id string) ([]Exercise, error) {
+	track, slug := "", filepath.ToSlash(id)
+	if i := strings.Index(slug, "/"); i >= 0 {
+		track, slug = slug[:i], slug[i+1:]
+	}
+
+	exercises, err := ws.Exercises()
+	if err != nil {
+		return nil, err
+	}
+	matches := []Exercise{}
+	for _, exercise := range exercises {
+		if exercise.Slug == slug && (track == "" || exercise.Track == track) {
+			matches = append(matches, exercise)
+		}
+	}
+	return matches, nil
+}
+
 // ExerciseDir determines the root directory of an exercise.
 // This is the directory that contains the exercise metadata file.
 func (ws Workspace) ExerciseDir(s string) (string, error) {
@@ -108,9 +165,26 @@ func (ws Workspace) ExerciseDir(s string) (string, error) {
 		return "", err
 	}
 
-	path := s
+	return findExerciseDir(s, ws.Dir)
+}
+
+// FindExerciseDir determines the root directory of the exercise that contains path,
+// without regard to the workspace.
+// This is the directory that contains the exercise metadata file.
+// It lets commands work from any subdirectory of an exercise.
+func FindExerciseDir(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return findExerciseDir(path, "")
+}
+
+// findExerciseDir walks up from path until it finds the exercise metadata file.
+// It gives up when it reaches stop, or the root of the filesystem.
+func findExerciseDir(path, stop string) (string, error) {
 	for {
-		if path == ws.Dir {
+		if path == stop {
 			return "", errMissingMetadata
 		}
 		if _, err := os.Lstat(path); os.IsNotExist(err) {
@@ -122,6 +196,10 @@ func (ws Workspace) ExerciseDir(s string) (string, error) {
 		if _, err := os.Lstat(filepath.Join(path, legacyMetadataFilename)); err == nil {
 			return path, nil
 		}
-		path = filepath.Dir(path)
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", errMissingMetadata
+		}
+		path = parent
 	}
 }
@@ -10,6 +10,16 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// writeTestExercise writes an exercise with its metadata and a solution.txt to dir.
+func writeTestExercise(t *testing.T, dir string, em ExerciseMetadata) *ExerciseMetadata {
+	t.Helper()
+	err := em.Write(dir)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "solution.txt"), []byte("solution"), os.FileMode(0644))
+	assert.NoError(t, err)
+	return &em
+}
+
 func TestWorkspacePotentialExercises(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "walk")
 	defer os.RemoveAll(tmpDir)
@@ -87,6 +97,41 @@ func TestWorkspaceExercises(t *testing.T) {
 	}
 }
 
+func TestWorkspaceCommunityExercises(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "walk-community")
+	defer os.RemoveAll(tmpDir)
+	assert.NoError(t, err)
+
+	ws, err := New(tmpDir)
+	assert.NoError(t, err)
+
+	exercises, err := ws.CommunityExercises()
+	assert.NoError(t, err)
+	assert.Empty(t, exercises)
+
+	own := filepath.Join(tmpDir, "track-a", "exercise-one")
+	alice := filepath.Join(tmpDir, "users", "alice", "track-a", "exercise-one")
+	bob := filepath.Join(tmpDir, "users", "bob", "track-b", "exercise-two")
+	noMetadata := filepath.Join(tmpDir, "users", "bob", "track-b", "exercise-three")
+	for _, path := range []string{own, alice, bob, noMetadata} {
+		metadataAbsoluteFilepath := filepath.Join(path, metadataFilepath)
+		err := os.MkdirAll(filepath.Dir(metadataAbsoluteFilepath), os.FileMode(0755))
+		assert.NoError(t, err)
+
+		if path != noMetadata {
+			err = os.WriteFile(metadataAbsoluteFilepath, []byte{}, os.FileMode(0600))
+			assert.NoError(t, err)
+		}
+	}
+
+	exercises, err = ws.CommunityExercises()
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(exercises)) {
+		assert.Equal(t, alice, exercises[0].Filepath())
+		assert.Equal(t, bob, exercises[1].Filepath())
+	}
+}
+
 func TestExerciseDir(t *testing.T) {
 	_, cwd, _, _ := runtime.Caller(0)
 	root := filepath.Join(cwd, "..", "..", "fixtures", "solution-dir")
@@ -137,3 +182,65 @@ func TestExerciseDir(t *testing.T) {
 		assert.Equal(t, filepath.Join(ws.Dir, "exercise"), dir, test.path)
 	}
 }
+
+func TestFindExerciseDir(t *testing.T) {
+	_, cwd, _, _ := runtime.Caller(0)
+	root := filepath.Join(cwd, "..", "..", "fixtures", "solution-dir")
+	exercise := filepath.Join(root, "workspace", "exercise")
+
+	for _, path := range []string{
+		exercise,
+		filepath.Join(exercise, "file.txt"),
+		filepath.Join(exercise, "in", "a", "subdir"),
+	} {
+		dir, err := FindExerciseDir(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, filepath.Clean(exercise), dir, path)
+	}
+
+	_, err := FindExerciseDir(filepath.Join(root, "workspace", "not-exercise"))
+	assert.True(t, IsMissingMetadata(err))
+}
+
+func TestWorkspaceFindExercises(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "find-exercises")
+	defer os.RemoveAll(tmpDir)
+	assert.NoError(t, err)
+
+	for _, path := range []string{
+		filepath.Join(tmpDir, "go", "two-fer"),
+		filepath.Join(tmpDir, "python", "two-fer"),
+		filepath.Join(tmpDir, "python", "leap"),
+		filepath.Join(tmpDir, "users", "alice", "go", "leap"),
+	} {
+		err := os.MkdirAll(filepath.Join(path, ignoreSubdir), os.FileMode(0755))
+		assert.NoError(t, err)
+		err = os.WriteFile(filepath.Join(path, metadataFilepath), []byte{}, os.FileMode(0600))
+		assert.NoError(t, err)
+	}
+
+	ws, err := New(tmpDir)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		id       string
+		expected []string
+	}{
+		{"two-fer", []string{"go/two-fer", "python/two-fer"}},
+		{"go/two-fer", []string{"go/two-fer"}},
+		{"leap", []string{"python/leap"}},
+		{"go/leap", []string{}},
+		{"hello-world", []string{}},
+	}
+
+	for _, tc := range testCases {
+		exercises, err := ws.FindExercises(tc.id)
+		assert.NoError(t, err)
+		paths := make([]string, len(exercises))
+		for i, e := range exercises {
+			paths[i] = e.Path()
+		}
+		sort.Strings(paths)
+		assert.Equal(t, tc.expected, paths, tc.id)
+	}
+}